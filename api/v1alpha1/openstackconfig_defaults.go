@@ -0,0 +1,131 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+	"time"
+)
+
+// OpenstackConfigSettings 기본값. CR과 OpenstackOperatorConfig 모두 값을 두지 않았을 때 사용한다.
+const (
+	DefaultContrabassTimeout          = 30 * time.Second
+	DefaultOpenstackTimeout           = 30 * time.Second
+	DefaultOpenstackEndpointInterface = "public"
+	DefaultDownPortFastRetryMax       = 5
+
+	DefaultPollFastInterval  = 20 * time.Second
+	DefaultPollSlowInterval  = 2 * time.Minute
+	DefaultPollErrorInterval = 30 * time.Second
+	DefaultPollFastWindow    = 3 * time.Minute
+
+	DefaultHTTPRetryMaxAttempts = 3
+	DefaultHTTPRetryBaseDelay   = 500 * time.Millisecond
+	DefaultHTTPRetryMaxDelay    = 10 * time.Second
+)
+
+// DefaultOpenstackPortAllowedStatuses는 기본으로 처리하는 포트 상태 목록이다.
+var DefaultOpenstackPortAllowedStatuses = []string{"ACTIVE", "DOWN"}
+
+// DefaultContrabassEncryptKeySecret은 encrypt key SecretRef가 없을 때 찾는 Secret 이름/키이다.
+const (
+	DefaultContrabassEncryptKeySecretName = "contrabass-encrypt-key"
+	DefaultContrabassEncryptKeySecretKey  = "CONTRABASS_ENCRYPT_KEY"
+)
+
+// CA bundle 참조 기본값.
+const (
+	CABundleKindSecret    = "Secret"
+	CABundleKindConfigMap = "ConfigMap"
+	DefaultCABundleKey    = "ca.crt"
+)
+
+// DefaultOperatorConfigName은 operatorConfigName이 비어 있을 때 참조하는 OpenstackOperatorConfig 이름이다.
+const DefaultOperatorConfigName = "default"
+
+// MergeSettings는 override에 값이 없는 필드를 base로 채운 새 settings를 반환한다.
+// 두 입력은 변경하지 않는다.
+func MergeSettings(base, override *OpenstackConfigSettings) *OpenstackConfigSettings {
+	out := &OpenstackConfigSettings{}
+	if override != nil {
+		out = override.DeepCopy()
+	}
+	if base == nil {
+		return out
+	}
+	mergeString(&out.ContrabassEndpoint, base.ContrabassEndpoint)
+	mergeString(&out.ContrabassEncryptKey, base.ContrabassEncryptKey)
+	mergeString(&out.ContrabassTimeout, base.ContrabassTimeout)
+	mergeBool(&out.ContrabassInsecureTLS, base.ContrabassInsecureTLS)
+	mergeString(&out.ViolaEndpoint, base.ViolaEndpoint)
+	mergeString(&out.OpenstackTimeout, base.OpenstackTimeout)
+	mergeBool(&out.OpenstackInsecureTLS, base.OpenstackInsecureTLS)
+	mergeString(&out.OpenstackNeutronEndpoint, base.OpenstackNeutronEndpoint)
+	mergeString(&out.OpenstackNovaEndpoint, base.OpenstackNovaEndpoint)
+	mergeString(&out.OpenstackEndpointInterface, base.OpenstackEndpointInterface)
+	mergeString(&out.OpenstackEndpointRegion, base.OpenstackEndpointRegion)
+	mergeString(&out.OpenstackNodeNameMetadataKey, base.OpenstackNodeNameMetadataKey)
+	if len(out.OpenstackPortAllowedStatuses) == 0 && len(base.OpenstackPortAllowedStatuses) > 0 {
+		out.OpenstackPortAllowedStatuses = append([]string(nil), base.OpenstackPortAllowedStatuses...)
+	}
+	if out.DownPortFastRetryMax == nil && base.DownPortFastRetryMax != nil {
+		v := *base.DownPortFastRetryMax
+		out.DownPortFastRetryMax = &v
+	}
+	mergeString(&out.PollFastInterval, base.PollFastInterval)
+	mergeString(&out.PollSlowInterval, base.PollSlowInterval)
+	mergeString(&out.PollErrorInterval, base.PollErrorInterval)
+	mergeString(&out.PollFastWindow, base.PollFastWindow)
+	if out.HTTPRetryMaxAttempts == nil && base.HTTPRetryMaxAttempts != nil {
+		v := *base.HTTPRetryMaxAttempts
+		out.HTTPRetryMaxAttempts = &v
+	}
+	mergeString(&out.HTTPRetryBaseDelay, base.HTTPRetryBaseDelay)
+	mergeString(&out.HTTPRetryMaxDelay, base.HTTPRetryMaxDelay)
+	if out.ContrabassRetry == nil && base.ContrabassRetry != nil {
+		out.ContrabassRetry = base.ContrabassRetry.DeepCopy()
+	}
+	if out.OpenstackRetry == nil && base.OpenstackRetry != nil {
+		out.OpenstackRetry = base.OpenstackRetry.DeepCopy()
+	}
+	if out.ViolaRetry == nil && base.ViolaRetry != nil {
+		out.ViolaRetry = base.ViolaRetry.DeepCopy()
+	}
+	if out.ContrabassTLS == nil && base.ContrabassTLS != nil {
+		out.ContrabassTLS = base.ContrabassTLS.DeepCopy()
+	}
+	if out.OpenstackTLS == nil && base.OpenstackTLS != nil {
+		out.OpenstackTLS = base.OpenstackTLS.DeepCopy()
+	}
+	if out.ViolaTLS == nil && base.ViolaTLS != nil {
+		out.ViolaTLS = base.ViolaTLS.DeepCopy()
+	}
+	return out
+}
+
+func mergeString(dst *string, base string) {
+	if strings.TrimSpace(*dst) == "" {
+		*dst = base
+	}
+}
+
+func mergeBool(dst **bool, base *bool) {
+	if *dst == nil && base != nil {
+		v := *base
+		*dst = &v
+	}
+}
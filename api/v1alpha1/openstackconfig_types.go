@@ -24,6 +24,7 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // OpenstackConfigSpec defines the desired state of OpenstackConfig
+// +kubebuilder:validation:XValidation:rule="(has(self.vmNames) && size(self.vmNames) > 0) || has(self.vmSelector) || has(self.nodeDiscovery)",message="vmNames, vmSelector or nodeDiscovery is required"
 type OpenstackConfigSpec struct {
 	// subnetIDs is the list of OpenStack subnet IDs to target.
 	// subnetIDs가 있으면 subnetID/subnetName을 무시한다.
@@ -41,13 +42,29 @@ type OpenstackConfigSpec struct {
 	SubnetName string `json:"subnetName,omitempty"`
 
 	// vmNames is the list of OpenStack VM IDs to configure.
-	// +kubebuilder:validation:MinItems=1
-	VmNames []string `json:"vmNames"`
+	// vmSelector와 함께 지정하면 두 결과를 합친다.
+	// +optional
+	VmNames []string `json:"vmNames,omitempty"`
+
+	// vmSelector discovers target VMs from Nova servers in the project.
+	// vmNames 대신(또는 함께) 사용하며, 조건은 모두 AND로 적용된다.
+	// +optional
+	VMSelector *VMSelector `json:"vmSelector,omitempty"`
+
+	// nodeDiscovery derives target VM IDs and node names from biz-cluster Node providerIDs.
+	// 지정하면 Node 이름을 nodeName으로 사용하므로 Nova 이름 매핑이 필요 없다.
+	// +optional
+	NodeDiscovery *NodeDiscovery `json:"nodeDiscovery,omitempty"`
 
 	// credentials contains provider and project identifiers.
 	Credentials OpenstackCredentials `json:"credentials"`
 
-	// settings overrides operator-level defaults for this CR.
+	// operatorConfigName selects the cluster-scoped OpenstackOperatorConfig used as settings defaults.
+	// 비어 있으면 "default" OpenstackOperatorConfig가 있을 때 사용한다.
+	// +optional
+	OperatorConfigName string `json:"operatorConfigName,omitempty"`
+
+	// settings overrides operator-level defaults (OpenstackOperatorConfig) for this CR.
 	// +optional
 	Settings *OpenstackConfigSettings `json:"settings,omitempty"`
 
@@ -56,6 +73,31 @@ type OpenstackConfigSpec struct {
 	Secrets *OpenstackConfigSecrets `json:"secrets,omitempty"`
 }
 
+// VMSelector defines how to discover VMs from Nova.
+type VMSelector struct {
+	// matchMetadata selects servers whose Nova metadata contains all key/value pairs.
+	// +optional
+	MatchMetadata map[string]string `json:"matchMetadata,omitempty"`
+
+	// nameRegex selects servers whose name matches the regular expression (Go RE2 syntax).
+	// +optional
+	NameRegex string `json:"nameRegex,omitempty"`
+
+	// tags selects servers that have all of the given Nova tags.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// NodeDiscovery defines how to discover VMs from Kubernetes Nodes in the biz cluster.
+type NodeDiscovery struct {
+	// kubeconfigSecretRef references the biz-cluster kubeconfig (same namespace).
+	KubeconfigSecretRef SecretKeyRef `json:"kubeconfigSecretRef"`
+
+	// nodeSelector limits discovery to Nodes that have all of the given labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
 // OpenstackCredentials defines the identifiers needed to resolve OpenStack access.
 type OpenstackCredentials struct {
 	// openstackProviderID is the provider ID used by Contrabass API.
@@ -83,10 +125,12 @@ type OpenstackConfigSettings struct {
 	ContrabassEncryptKey string `json:"contrabassEncryptKey,omitempty"`
 
 	// contrabassTimeout is the HTTP timeout (e.g. 30s).
+	// Built-in default: 30s.
 	// +optional
 	ContrabassTimeout string `json:"contrabassTimeout,omitempty"`
 
 	// contrabassInsecureTLS allows insecure TLS.
+	// Built-in default: false.
 	// +optional
 	ContrabassInsecureTLS *bool `json:"contrabassInsecureTLS,omitempty"`
 
@@ -95,10 +139,12 @@ type OpenstackConfigSettings struct {
 	ViolaEndpoint string `json:"violaEndpoint,omitempty"`
 
 	// openstackTimeout is the HTTP timeout (e.g. 30s).
+	// Built-in default: 30s.
 	// +optional
 	OpenstackTimeout string `json:"openstackTimeout,omitempty"`
 
 	// openstackInsecureTLS allows insecure TLS.
+	// Built-in default: false.
 	// +optional
 	OpenstackInsecureTLS *bool `json:"openstackInsecureTLS,omitempty"`
 
@@ -111,6 +157,7 @@ type OpenstackConfigSettings struct {
 	OpenstackNovaEndpoint string `json:"openstackNovaEndpoint,omitempty"`
 
 	// openstackEndpointInterface selects endpoint interface (public/internal/admin).
+	// Built-in default: public.
 	// +optional
 	OpenstackEndpointInterface string `json:"openstackEndpointInterface,omitempty"`
 
@@ -123,28 +170,121 @@ type OpenstackConfigSettings struct {
 	OpenstackNodeNameMetadataKey string `json:"openstackNodeNameMetadataKey,omitempty"`
 
 	// openstackPortAllowedStatuses filters port statuses (e.g. ACTIVE, DOWN).
+	// Built-in default: [ACTIVE, DOWN].
 	// +optional
 	OpenstackPortAllowedStatuses []string `json:"openstackPortAllowedStatuses,omitempty"`
 
 	// downPortFastRetryMax controls fast retry count for DOWN ports.
+	// Built-in default: 5.
 	// +optional
 	DownPortFastRetryMax *int32 `json:"downPortFastRetryMax,omitempty"`
 
 	// pollFastInterval is the fast polling interval.
+	// Built-in default: 20s.
 	// +optional
 	PollFastInterval string `json:"pollFastInterval,omitempty"`
 
 	// pollSlowInterval is the slow polling interval.
+	// Built-in default: 2m.
 	// +optional
 	PollSlowInterval string `json:"pollSlowInterval,omitempty"`
 
 	// pollErrorInterval is the retry interval on error.
+	// Built-in default: 30s.
 	// +optional
 	PollErrorInterval string `json:"pollErrorInterval,omitempty"`
 
 	// pollFastWindow is the fast polling window after changes.
+	// Built-in default: 3m.
 	// +optional
 	PollFastWindow string `json:"pollFastWindow,omitempty"`
+
+	// httpRetryMaxAttempts is the max attempts (including the first) for retryable API requests.
+	// 1이면 재시도하지 않는다. (429/5xx/연결 오류만 재시도)
+	// Built-in default: 3.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	HTTPRetryMaxAttempts *int32 `json:"httpRetryMaxAttempts,omitempty"`
+
+	// httpRetryBaseDelay is the backoff before the first retry (doubled per attempt, with jitter).
+	// Built-in default: 500ms.
+	// +optional
+	HTTPRetryBaseDelay string `json:"httpRetryBaseDelay,omitempty"`
+
+	// httpRetryMaxDelay caps the backoff between retries. Retry-After longer than this is not waited for.
+	// Built-in default: 10s.
+	// +optional
+	HTTPRetryMaxDelay string `json:"httpRetryMaxDelay,omitempty"`
+
+	// contrabassRetry overrides the httpRetry* policy for Contrabass API.
+	// +optional
+	ContrabassRetry *HTTPRetrySettings `json:"contrabassRetry,omitempty"`
+
+	// openstackRetry overrides the httpRetry* policy for Keystone/Neutron/Nova.
+	// +optional
+	OpenstackRetry *HTTPRetrySettings `json:"openstackRetry,omitempty"`
+
+	// violaRetry overrides the httpRetry* policy for Viola API.
+	// +optional
+	ViolaRetry *HTTPRetrySettings `json:"violaRetry,omitempty"`
+
+	// contrabassTLS configures CA bundle and client certificate for Contrabass API.
+	// +optional
+	ContrabassTLS *TLSSettings `json:"contrabassTLS,omitempty"`
+
+	// openstackTLS configures CA bundle and client certificate for Keystone/Neutron/Nova.
+	// +optional
+	OpenstackTLS *TLSSettings `json:"openstackTLS,omitempty"`
+
+	// violaTLS configures CA bundle and client certificate for Viola API.
+	// +optional
+	ViolaTLS *TLSSettings `json:"violaTLS,omitempty"`
+}
+
+// HTTPRetrySettings overrides the retry policy for one API.
+// 비어 있는 필드는 httpRetryMaxAttempts/httpRetryBaseDelay/httpRetryMaxDelay 값을 따른다.
+type HTTPRetrySettings struct {
+	// maxAttempts is the max attempts (including the first). 1이면 재시도하지 않는다.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+
+	// baseDelay is the backoff before the first retry.
+	// +optional
+	BaseDelay string `json:"baseDelay,omitempty"`
+
+	// maxDelay caps the backoff between retries.
+	// +optional
+	MaxDelay string `json:"maxDelay,omitempty"`
+}
+
+// TLSSettings defines server verification and client authentication for an API.
+// 참조하는 Secret/ConfigMap은 OpenstackConfig와 같은 네임스페이스에서 찾는다.
+type TLSSettings struct {
+	// caBundleRef references PEM CA certificates trusted in addition to the system roots.
+	// +optional
+	CABundleRef *CABundleRef `json:"caBundleRef,omitempty"`
+
+	// clientCertSecretName is a kubernetes.io/tls Secret (tls.crt/tls.key) used for mTLS.
+	// +optional
+	ClientCertSecretName string `json:"clientCertSecretName,omitempty"`
+}
+
+// CABundleRef references a PEM CA bundle in a Secret or ConfigMap.
+type CABundleRef struct {
+	// kind is Secret or ConfigMap.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +kubebuilder:default=ConfigMap
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// name is the Secret or ConfigMap name.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// key is the data key holding the PEM bundle. (기본값: ca.crt)
+	// +optional
+	Key string `json:"key,omitempty"`
 }
 
 // SecretKeyRef defines a secret reference.
@@ -180,6 +320,40 @@ type DownPortRetryStatus struct {
 	FastAttempts int32 `json:"fastAttempts,omitempty"`
 }
 
+// NodeStatus는 VM/노드별 매핑 및 전송 상태를 기록한다.
+type NodeStatus struct {
+	// vmID는 OpenStack VM ID이다.
+	VMID string `json:"vmID"`
+
+	// nodeName은 결정된 K8s nodeName이다.
+	// +optional
+	NodeName string `json:"nodeName,omitempty"`
+
+	// interfaceCount는 전송 대상 인터페이스 수이다.
+	// +optional
+	InterfaceCount int32 `json:"interfaceCount,omitempty"`
+
+	// portIDs는 인터페이스로 매핑된 Neutron 포트 ID 목록(정렬)이다.
+	// +optional
+	PortIDs []string `json:"portIDs,omitempty"`
+
+	// hash는 마지막으로 계산한 NodeConfig 해시값이다.
+	// +optional
+	Hash string `json:"hash,omitempty"`
+
+	// lastSentAt은 Viola에 마지막으로 전송한 시각이다.
+	// +optional
+	LastSentAt *metav1.Time `json:"lastSentAt,omitempty"`
+
+	// error는 이 노드 처리 중 발생한 마지막 오류이다.
+	// +optional
+	Error string `json:"error,omitempty"`
+
+	// warning은 처리는 성공했지만 확인이 필요한 상태이다. (예: nodeName 미결정으로 vm id 사용)
+	// +optional
+	Warning string `json:"warning,omitempty"`
+}
+
 // OpenstackConfigStatus defines the observed state of OpenstackConfig.
 type OpenstackConfigStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -213,6 +387,16 @@ type OpenstackConfigStatus struct {
 	// downPortRetry는 DOWN 포트 재전송 상태를 기록한다.
 	// +optional
 	DownPortRetry *DownPortRetryStatus `json:"downPortRetry,omitempty"`
+
+	// resolvedVMIDs는 vmNames/vmSelector/nodeDiscovery로 결정된 대상 VM ID 목록(정렬)이다.
+	// +optional
+	ResolvedVMIDs []string `json:"resolvedVMIDs,omitempty"`
+
+	// nodes는 VM/노드별 상태 목록(vmID 정렬)이다.
+	// +listType=map
+	// +listMapKey=vmID
+	// +optional
+	Nodes []NodeStatus `json:"nodes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,58 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenstackOperatorConfigSpec defines operator-wide defaults for OpenstackConfig.
+type OpenstackOperatorConfigSpec struct {
+	// settings are used for every OpenstackConfig field that the CR does not set.
+	// 우선순위: OpenstackConfig settings > OpenstackOperatorConfig settings > 오퍼레이터 기본값
+	// +optional
+	Settings *OpenstackConfigSettings `json:"settings,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// OpenstackOperatorConfig is the Schema for the openstackoperatorconfigs API
+type OpenstackOperatorConfig struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines operator-wide defaults
+	// +required
+	Spec OpenstackOperatorConfigSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenstackOperatorConfigList contains a list of OpenstackOperatorConfig
+type OpenstackOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []OpenstackOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenstackOperatorConfig{}, &OpenstackOperatorConfigList{})
+}
@@ -25,6 +25,107 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleRef) DeepCopyInto(out *CABundleRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleRef.
+func (in *CABundleRef) DeepCopy() *CABundleRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DownPortRetryStatus) DeepCopyInto(out *DownPortRetryStatus) {
+	*out = *in
+	if in.LastAttempt != nil {
+		in, out := &in.LastAttempt, &out.LastAttempt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownPortRetryStatus.
+func (in *DownPortRetryStatus) DeepCopy() *DownPortRetryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DownPortRetryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPRetrySettings) DeepCopyInto(out *HTTPRetrySettings) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRetrySettings.
+func (in *HTTPRetrySettings) DeepCopy() *HTTPRetrySettings {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPRetrySettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDiscovery) DeepCopyInto(out *NodeDiscovery) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDiscovery.
+func (in *NodeDiscovery) DeepCopy() *NodeDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
+	*out = *in
+	if in.PortIDs != nil {
+		in, out := &in.PortIDs, &out.PortIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSentAt != nil {
+		in, out := &in.LastSentAt, &out.LastSentAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
+func (in *NodeStatus) DeepCopy() *NodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenstackConfig) DeepCopyInto(out *OpenstackConfig) {
 	*out = *in
@@ -84,15 +185,130 @@ func (in *OpenstackConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenstackConfigSecrets) DeepCopyInto(out *OpenstackConfigSecrets) {
+	*out = *in
+	if in.ContrabassEncryptKeySecretRef != nil {
+		in, out := &in.ContrabassEncryptKeySecretRef, &out.ContrabassEncryptKeySecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackConfigSecrets.
+func (in *OpenstackConfigSecrets) DeepCopy() *OpenstackConfigSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenstackConfigSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenstackConfigSettings) DeepCopyInto(out *OpenstackConfigSettings) {
+	*out = *in
+	if in.ContrabassInsecureTLS != nil {
+		in, out := &in.ContrabassInsecureTLS, &out.ContrabassInsecureTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OpenstackInsecureTLS != nil {
+		in, out := &in.OpenstackInsecureTLS, &out.OpenstackInsecureTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OpenstackPortAllowedStatuses != nil {
+		in, out := &in.OpenstackPortAllowedStatuses, &out.OpenstackPortAllowedStatuses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DownPortFastRetryMax != nil {
+		in, out := &in.DownPortFastRetryMax, &out.DownPortFastRetryMax
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HTTPRetryMaxAttempts != nil {
+		in, out := &in.HTTPRetryMaxAttempts, &out.HTTPRetryMaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ContrabassRetry != nil {
+		in, out := &in.ContrabassRetry, &out.ContrabassRetry
+		*out = new(HTTPRetrySettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenstackRetry != nil {
+		in, out := &in.OpenstackRetry, &out.OpenstackRetry
+		*out = new(HTTPRetrySettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ViolaRetry != nil {
+		in, out := &in.ViolaRetry, &out.ViolaRetry
+		*out = new(HTTPRetrySettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContrabassTLS != nil {
+		in, out := &in.ContrabassTLS, &out.ContrabassTLS
+		*out = new(TLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OpenstackTLS != nil {
+		in, out := &in.OpenstackTLS, &out.OpenstackTLS
+		*out = new(TLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ViolaTLS != nil {
+		in, out := &in.ViolaTLS, &out.ViolaTLS
+		*out = new(TLSSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackConfigSettings.
+func (in *OpenstackConfigSettings) DeepCopy() *OpenstackConfigSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenstackConfigSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenstackConfigSpec) DeepCopyInto(out *OpenstackConfigSpec) {
 	*out = *in
+	if in.SubnetIDs != nil {
+		in, out := &in.SubnetIDs, &out.SubnetIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.VmNames != nil {
 		in, out := &in.VmNames, &out.VmNames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.VMSelector != nil {
+		in, out := &in.VMSelector, &out.VMSelector
+		*out = new(VMSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeDiscovery != nil {
+		in, out := &in.NodeDiscovery, &out.NodeDiscovery
+		*out = new(NodeDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 	out.Credentials = in.Credentials
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(OpenstackConfigSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = new(OpenstackConfigSecrets)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackConfigSpec.
@@ -124,6 +340,18 @@ func (in *OpenstackConfigStatus) DeepCopyInto(out *OpenstackConfigStatus) {
 		*out = new(DownPortRetryStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResolvedVMIDs != nil {
+		in, out := &in.ResolvedVMIDs, &out.ResolvedVMIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]NodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackConfigStatus.
@@ -141,31 +369,152 @@ func (in *OpenstackCredentials) DeepCopyInto(out *OpenstackCredentials) {
 	*out = *in
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackCredentials.
+func (in *OpenstackCredentials) DeepCopy() *OpenstackCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenstackCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DownPortRetryStatus) DeepCopyInto(out *DownPortRetryStatus) {
+func (in *OpenstackOperatorConfig) DeepCopyInto(out *OpenstackOperatorConfig) {
 	*out = *in
-	if in.LastAttempt != nil {
-		in, out := &in.LastAttempt, &out.LastAttempt
-		*out = (*in).DeepCopy()
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackOperatorConfig.
+func (in *OpenstackOperatorConfig) DeepCopy() *OpenstackOperatorConfig {
+	if in == nil {
+		return nil
 	}
+	out := new(OpenstackOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DownPortRetryStatus.
-func (in *DownPortRetryStatus) DeepCopy() *DownPortRetryStatus {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenstackOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenstackOperatorConfigList) DeepCopyInto(out *OpenstackOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenstackOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackOperatorConfigList.
+func (in *OpenstackOperatorConfigList) DeepCopy() *OpenstackOperatorConfigList {
 	if in == nil {
 		return nil
 	}
-	out := new(DownPortRetryStatus)
+	out := new(OpenstackOperatorConfigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackCredentials.
-func (in *OpenstackCredentials) DeepCopy() *OpenstackCredentials {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenstackOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenstackOperatorConfigSpec) DeepCopyInto(out *OpenstackOperatorConfigSpec) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(OpenstackConfigSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenstackOperatorConfigSpec.
+func (in *OpenstackOperatorConfigSpec) DeepCopy() *OpenstackOperatorConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OpenstackCredentials)
+	out := new(OpenstackOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSSettings) DeepCopyInto(out *TLSSettings) {
+	*out = *in
+	if in.CABundleRef != nil {
+		in, out := &in.CABundleRef, &out.CABundleRef
+		*out = new(CABundleRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSettings.
+func (in *TLSSettings) DeepCopy() *TLSSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSelector) DeepCopyInto(out *VMSelector) {
+	*out = *in
+	if in.MatchMetadata != nil {
+		in, out := &in.MatchMetadata, &out.MatchMetadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSelector.
+func (in *VMSelector) DeepCopy() *VMSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSelector)
 	in.DeepCopyInto(out)
 	return out
 }
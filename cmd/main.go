@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -42,6 +43,8 @@ import (
 	multinicv1alpha1 "multinic-operator/api/v1alpha1"
 	"multinic-operator/internal/controller"
 	"multinic-operator/internal/inventory"
+	webhookv1alpha1 "multinic-operator/internal/webhook/v1alpha1"
+	"multinic-operator/pkg/notification"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -185,36 +188,77 @@ func main() {
 
 	inventoryEnabled := getenvBool("INVENTORY_ENABLED", true)
 	inventoryAddr := getenv("INVENTORY_ADDR", ":18081")
-	inventoryDBPath := getenv("INVENTORY_DB_PATH", "/var/lib/multinic-operator/inventory.json")
+	inventoryBackend := getenv("INVENTORY_BACKEND", inventory.BackendFile)
+	defaultInventoryDBPath := "/var/lib/multinic-operator/inventory.json"
+	if inventoryBackend == inventory.BackendSQLite {
+		defaultInventoryDBPath = "/var/lib/multinic-operator/inventory.db"
+	}
+	inventoryDBPath := getenv("INVENTORY_DB_PATH", defaultInventoryDBPath)
+	inventoryDSN := getenv("INVENTORY_DSN", "")
+	inventoryRetention := inventory.HistoryRetention{
+		MaxRevisions: getenvInt("INVENTORY_HISTORY_MAX_REVISIONS", inventory.DefaultHistoryMaxRevisions),
+		MaxAge:       getenvDuration("INVENTORY_HISTORY_MAX_AGE", inventory.DefaultHistoryMaxAge),
+	}
+	inventoryPruneInterval := getenvDuration("INVENTORY_HISTORY_PRUNE_INTERVAL", inventory.DefaultHistoryPruneInterval)
 	violaEndpoint := getenv("VIOLA_ENDPOINT", "")
 	violaTimeout := getenvDuration("VIOLA_TIMEOUT", 30*time.Second)
 	violaInsecure := getenvBool("VIOLA_INSECURE_TLS", false)
+	providerCacheTTL := getenvDuration("CONTRABASS_PROVIDER_CACHE_TTL", 5*time.Minute)
+	notificationsEnabled := getenvBool("NOTIFICATIONS_ENABLED", false)
+	notificationExchanges := getenvList("NOTIFICATIONS_EXCHANGES", notification.DefaultExchanges)
+	notificationTopics := getenvList("NOTIFICATIONS_TOPICS", notification.DefaultTopics)
 
-	var invStore *inventory.Store
+	var invStore inventory.Backend
 	if inventoryEnabled {
-		store, err := inventory.NewStore(inventoryDBPath)
+		store, err := inventory.Open(inventoryBackend, inventoryDBPath, inventoryDSN,
+			inventory.WithHistoryRetention(inventoryRetention))
 		if err != nil {
-			setupLog.Error(err, "unable to open inventory db", "path", inventoryDBPath)
+			setupLog.Error(err, "unable to open inventory db", "backend", inventoryBackend, "path", inventoryDBPath)
 			os.Exit(1)
 		}
 		if err := store.Init(context.Background()); err != nil {
 			setupLog.Error(err, "unable to init inventory db")
 			os.Exit(1)
 		}
-		invStore = store
+		// Inventory API watch(SSE)는 이 프로세스의 upsert 알림을 사용한다.
+		invStore = inventory.NewNotifier(store, inventory.DefaultWatchBuffer)
+		// 기간이 지난 이력 전체 정리는 leader에서만 주기적으로 실행한다. (Upsert는 해당 노드만 정리)
+		if err := mgr.Add(inventory.NewHistoryPruner(ctrl.Log.WithName("inventory"), store, inventoryPruneInterval)); err != nil {
+			setupLog.Error(err, "unable to set up inventory history pruner")
+			os.Exit(1)
+		}
+	}
+
+	var notifications *notification.Listener
+	if notificationsEnabled {
+		notifications = notification.NewListener(
+			ctrl.Log.WithName("notification"),
+			notification.WithExchanges(notificationExchanges),
+			notification.WithTopics(notificationTopics),
+		)
 	}
 
 	if err := (&controller.OpenstackConfigReconciler{
-		Client:            mgr.GetClient(),
-		Scheme:            mgr.GetScheme(),
-		Inventory:         invStore,
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		Recorder:         mgr.GetEventRecorderFor("openstackconfig-controller"),
+		Inventory:        invStore,
 		ViolaEndpoint:    violaEndpoint,
 		ViolaTimeout:     violaTimeout,
 		ViolaInsecureTLS: violaInsecure,
+		ProviderCacheTTL: providerCacheTTL,
+		Notifications:    notifications,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "OpenstackConfig")
 		os.Exit(1)
 	}
+	// 인증서가 준비된 경우에만 켠다. (config/default의 manager_webhook_patch 또는 Helm webhook.enabled)
+	if getenvBool("ENABLE_WEBHOOKS", false) {
+		if err := webhookv1alpha1.SetupOpenstackConfigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OpenstackConfig")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -228,7 +272,8 @@ func main() {
 
 	ctx := ctrl.SetupSignalHandler()
 	if inventoryEnabled {
-		server := inventory.NewServer(inventoryAddr, invStore)
+		// watch 이벤트는 컨트롤러가 실행되는 leader에서만 발행되므로 다른 replica는 watch를 거부한다.
+		server := inventory.NewServer(inventoryAddr, invStore, inventory.WithLeaderElection(mgr.Elected()))
 		go func() {
 			if err := server.Start(ctx); err != nil && err != http.ErrServerClosed {
 				setupLog.Error(err, "inventory server failed")
@@ -241,6 +286,11 @@ func main() {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	if invStore != nil {
+		if err := invStore.Close(); err != nil {
+			setupLog.Error(err, "failed to close inventory db")
+		}
+	}
 }
 
 func getenv(key, def string) string {
@@ -260,6 +310,15 @@ func getenvBool(key string, def bool) bool {
 	return def
 }
 
+func getenvInt(key string, def int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
 func getenvDuration(key string, def time.Duration) time.Duration {
 	if v := os.Getenv(key); v != "" {
 		if d, err := time.ParseDuration(v); err == nil {
@@ -268,3 +327,16 @@ func getenvDuration(key string, def time.Duration) time.Duration {
 	}
 	return def
 }
+
+func getenvList(key string, def []string) []string {
+	var out []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	if len(out) == 0 {
+		return def
+	}
+	return out
+}
@@ -126,6 +126,18 @@ type applyResponse struct {
 	Output  string `json:"output,omitempty"`
 }
 
+type nodeRef struct {
+	NodeName   string `json:"nodeName"`
+	InstanceID string `json:"instanceId,omitempty"`
+}
+
+type deleteResponse struct {
+	Deleted int    `json:"deleted"`
+	Output  string `json:"output,omitempty"`
+}
+
+const nodeConfigResource = "multinicnodeconfigs.multinic.io"
+
 func main() {
 	namespace := getenv("TARGET_NAMESPACE", "multinic-system")
 	kubectlPath := getenv("KUBECTL_PATH", "kubectl")
@@ -142,7 +154,7 @@ func main() {
 	mux.HandleFunc("/healthz", srv.handleHealth)
 	mux.HandleFunc("/openapi.yaml", srv.handleOpenAPI)
 	mux.HandleFunc("/docs", srv.handleDocs)
-	mux.HandleFunc("/v1/k8s/multinic/node-configs", srv.handleApply)
+	mux.HandleFunc("/v1/k8s/multinic/node-configs", srv.handleNodeConfigs)
 
 	httpServer := &http.Server{
 		Addr:              listenAddr,
@@ -201,6 +213,18 @@ func (s *server) handleDocs(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte(swaggerHTML))
 }
 
+// handleNodeConfigs는 메서드에 따라 적용(POST)/삭제(DELETE)로 분기한다.
+func (s *server) handleNodeConfigs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.handleApply(w, r)
+	case http.MethodDelete:
+		s.handleDelete(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
 // handleApply는 Viola 요청을 받아 MultiNicNodeConfig를 적용한다.
 func (s *server) handleApply(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -263,20 +287,140 @@ func (s *server) handleApply(w http.ResponseWriter, r *http.Request) {
 	_ = enc.Encode(resp)
 }
 
+// handleDelete는 Viola 삭제 요청을 받아 MultiNicNodeConfig를 삭제한다.
+// body(JSON 배열) 또는 nodeName/instanceId 쿼리로 대상을 지정한다.
+func (s *server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	providerID := r.Header.Get("x-provider-id")
+
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	var refs []nodeRef
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &refs); err != nil {
+			http.Error(w, fmt.Sprintf("invalid json: %v", err), http.StatusBadRequest)
+			return
+		}
+	} else {
+		q := r.URL.Query()
+		if q.Get("nodeName") != "" || q.Get("instanceId") != "" {
+			refs = append(refs, nodeRef{NodeName: q.Get("nodeName"), InstanceID: q.Get("instanceId")})
+		}
+	}
+	if len(refs) == 0 {
+		http.Error(w, "empty payload", http.StatusBadRequest)
+		return
+	}
+
+	target, err := s.router.pickTarget(providerID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validateTarget(target); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	log.Printf("received %d node config deletions (provider=%q -> %s)", len(refs), providerID, targetSummary(target))
+
+	commands, err := buildDeleteCommands(refs, providerID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	outputs := make([]string, 0, len(commands))
+	for _, args := range commands {
+		output, err := s.runKubectl(r.Context(), target, nil, args...)
+		if err != nil {
+			log.Printf("kubectl delete failed: %v", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if output != "" {
+			outputs = append(outputs, output)
+		}
+	}
+	output := strings.Join(outputs, "\n")
+	if output != "" {
+		log.Printf("kubectl delete output: %s", output)
+	}
+
+	resp := deleteResponse{Deleted: len(refs), Output: output}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	_ = enc.Encode(resp)
+}
+
 func (s *server) applyManifest(ctx context.Context, target targetConfig, manifest []byte) (string, error) {
+	return s.runKubectl(ctx, target, manifest, "apply", "-f", "-")
+}
+
+// runKubectl은 대상 모드(local/ssh)에 맞춰 kubectl 명령을 실행한다.
+func (s *server) runKubectl(ctx context.Context, target targetConfig, stdin []byte, args ...string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.applyTimeout)
 	defer cancel()
 
 	switch strings.ToLower(target.Mode) {
 	case "", "local":
-		return applyViaKubectl(ctx, target, manifest)
+		return runViaKubectl(ctx, target, stdin, args...)
 	case "ssh":
-		return applyViaSSH(ctx, target, manifest)
+		return runViaSSH(ctx, target, stdin, args...)
 	default:
 		return "", fmt.Errorf("unsupported target mode: %s", target.Mode)
 	}
 }
 
+// buildDeleteCommands는 삭제 대상별 kubectl delete 인자를 만든다.
+// nodeName이 있으면 CR 이름으로, 없으면 instanceId(+providerId) 라벨로 삭제한다.
+func buildDeleteCommands(refs []nodeRef, providerID string) ([][]string, error) {
+	names := make([]string, 0, len(refs))
+	seenNames := make(map[string]struct{}, len(refs))
+	selectors := make([]string, 0)
+	seenSelectors := make(map[string]struct{})
+	for _, ref := range refs {
+		nodeName := strings.TrimSpace(ref.NodeName)
+		instanceID := strings.TrimSpace(ref.InstanceID)
+		switch {
+		case nodeName != "":
+			name := sanitizeName(nodeName)
+			if _, ok := seenNames[name]; ok {
+				continue
+			}
+			seenNames[name] = struct{}{}
+			names = append(names, name)
+		case instanceID != "":
+			selector := "multinic.io/instance-id=" + sanitizeLabel(instanceID)
+			if strings.TrimSpace(providerID) != "" {
+				selector += ",multinic.io/provider-id=" + sanitizeLabel(providerID)
+			}
+			if _, ok := seenSelectors[selector]; ok {
+				continue
+			}
+			seenSelectors[selector] = struct{}{}
+			selectors = append(selectors, selector)
+		default:
+			return nil, fmt.Errorf("nodeName or instanceId is required")
+		}
+	}
+
+	commands := make([][]string, 0, len(selectors)+1)
+	if len(names) > 0 {
+		args := append([]string{"delete", nodeConfigResource}, names...)
+		commands = append(commands, append(args, "--ignore-not-found"))
+	}
+	for _, selector := range selectors {
+		commands = append(commands, []string{"delete", nodeConfigResource, "-l", selector, "--ignore-not-found"})
+	}
+	return commands, nil
+}
+
 func buildManifest(configs []nodeConfig, namespace, providerID string) ([]byte, error) {
 	var docs []string
 	for _, cfg := range configs {
@@ -557,7 +701,7 @@ func targetSummary(target targetConfig) string {
 	return fmt.Sprintf("local %s/%s", target.KubeAPI, target.Namespace)
 }
 
-func applyViaKubectl(ctx context.Context, target targetConfig, manifest []byte) (string, error) {
+func runViaKubectl(ctx context.Context, target targetConfig, stdin []byte, kubectlArgs ...string) (string, error) {
 	args := []string{
 		"--server=" + target.KubeAPI,
 		"--certificate-authority=" + target.KubeCAPath,
@@ -566,18 +710,20 @@ func applyViaKubectl(ctx context.Context, target targetConfig, manifest []byte)
 	if target.Namespace != "" {
 		args = append(args, "--namespace="+target.Namespace)
 	}
-	args = append(args, "apply", "-f", "-")
+	args = append(args, kubectlArgs...)
 	cmd := exec.CommandContext(ctx, target.KubectlPath, args...)
 	cmd.Env = os.Environ()
-	cmd.Stdin = bytes.NewReader(manifest)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("kubectl apply failed: %w: %s", err, strings.TrimSpace(string(out)))
+		return "", fmt.Errorf("kubectl %s failed: %w: %s", kubectlVerb(kubectlArgs), err, strings.TrimSpace(string(out)))
 	}
 	return strings.TrimSpace(string(out)), nil
 }
 
-func applyViaSSH(ctx context.Context, target targetConfig, manifest []byte) (string, error) {
+func runViaSSH(ctx context.Context, target targetConfig, stdin []byte, kubectlArgs ...string) (string, error) {
 	sshpassPath, err := exec.LookPath("sshpass")
 	if err != nil {
 		return "", fmt.Errorf("sshpass is required for ssh mode")
@@ -603,18 +749,27 @@ func applyViaSSH(ctx context.Context, target targetConfig, manifest []byte) (str
 	if target.Namespace != "" {
 		args = append(args, "--namespace="+target.Namespace)
 	}
-	args = append(args, "apply", "-f", "-")
+	args = append(args, kubectlArgs...)
 
 	cmd := exec.CommandContext(ctx, sshpassPath, args...)
 	cmd.Env = os.Environ()
-	cmd.Stdin = bytes.NewReader(manifest)
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
 	out, err := cmd.CombinedOutput()
 	if err != nil {
-		return "", fmt.Errorf("ssh kubectl apply failed: %w: %s", err, strings.TrimSpace(string(out)))
+		return "", fmt.Errorf("ssh kubectl %s failed: %w: %s", kubectlVerb(kubectlArgs), err, strings.TrimSpace(string(out)))
 	}
 	return strings.TrimSpace(string(out)), nil
 }
 
+func kubectlVerb(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
 var interfaceNamePattern = regexp.MustCompile(`^multinic([0-9]+)$`)
 
 func parseInterfaceNameIndex(name string) (int, bool) {
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+	"multinic-operator/pkg/apierror"
+)
+
+// conflictRequeue는 409 응답 후 재시도 간격이다. 경합은 곧 해소되므로 짧게 둔다.
+const conflictRequeue = 5 * time.Second
+
+// apiErrorReason은 API 응답 코드에 따라 조건 reason을 세분화한다.
+// 예: NeutronPortError + 404 → NeutronPortNotFound. 응답 오류가 아니면(네트워크 오류 등) base를 그대로 쓴다.
+func apiErrorReason(base string, err error) string {
+	apiErr, ok := apierror.As(err)
+	if !ok {
+		return base
+	}
+	prefix := strings.TrimSuffix(base, "Error")
+	switch code := apiErr.StatusCode; {
+	case code == http.StatusUnauthorized:
+		return prefix + "Unauthorized"
+	case code == http.StatusForbidden:
+		return prefix + "Forbidden"
+	case code == http.StatusNotFound:
+		return prefix + "NotFound"
+	case code == http.StatusConflict:
+		return prefix + "Conflict"
+	case code == http.StatusTooManyRequests:
+		return prefix + "RateLimited"
+	case code >= http.StatusInternalServerError:
+		return prefix + "Unavailable"
+	case code >= http.StatusBadRequest:
+		return prefix + "Rejected"
+	}
+	return base
+}
+
+// apiErrorRequeue는 API 응답 코드에 따라 재시도 간격을 정한다.
+//   - 429/5xx, 네트워크 오류: pollError (Retry-After가 더 길면 그 값)
+//   - 409: conflictRequeue
+//   - 그 외 4xx(인증/권한/대상 없음/잘못된 요청): 사람이 고쳐야 하므로 pollSlow
+func apiErrorRequeue(err error, pollError, pollSlow time.Duration) time.Duration {
+	apiErr, ok := apierror.As(err)
+	if !ok {
+		return pollError
+	}
+	switch {
+	case apiErr.Temporary():
+		if apiErr.RetryAfter > pollError {
+			return apiErr.RetryAfter
+		}
+		return pollError
+	case apiErr.StatusCode == http.StatusConflict:
+		return conflictRequeue
+	case apiErr.StatusCode >= http.StatusBadRequest:
+		return pollSlow
+	}
+	return pollError
+}
+
+// apiFailure는 API 호출 실패를 Ready=False 조건으로 기록하고 재시도 결과를 반환한다.
+func (r *OpenstackConfigReconciler) apiFailure(ctx context.Context, log logr.Logger, cfg *multinicv1alpha1.OpenstackConfig, base string, err error, pollError, pollSlow time.Duration) ctrl.Result {
+	r.setReadyCondition(ctx, log, cfg, metav1.ConditionFalse, apiErrorReason(base, err), err.Error())
+	return ctrl.Result{RequeueAfter: apiErrorRequeue(err, pollError, pollSlow)}
+}
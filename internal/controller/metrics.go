@@ -0,0 +1,193 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"multinic-operator/pkg/viola"
+)
+
+// 동기화 파이프라인 단계 이름 (stage 라벨 값)
+const (
+	stageContrabass = "contrabass"
+	stageKeystone   = "keystone"
+	stageNeutron    = "neutron"
+	stageNova       = "nova"
+	stageViola      = "viola"
+)
+
+// filterChanged 캐시 조회 결과 (result 라벨 값)
+const (
+	cacheResultHit       = "hit"
+	cacheResultInventory = "inventory"
+	cacheResultMiss      = "miss"
+)
+
+var (
+	syncStageDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "multinic_sync_stage_duration_seconds",
+			Help:    "Latency of each sync pipeline stage (contrabass, keystone, neutron, nova, viola).",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"stage"},
+	)
+	syncStageErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "multinic_sync_stage_errors_total",
+			Help: "Number of errors per sync pipeline stage.",
+		},
+		[]string{"stage"},
+	)
+	managedNodes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "multinic_managed_nodes",
+			Help: "Number of nodes with at least one interface managed per provider and OpenstackConfig.",
+		},
+		[]string{"provider", "config"},
+	)
+	managedInterfaces = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "multinic_managed_interfaces",
+			Help: "Number of interfaces managed per provider and OpenstackConfig.",
+		},
+		[]string{"provider", "config"},
+	)
+	downPortsOutstanding = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "multinic_down_ports",
+			Help: "Number of DOWN ports still waiting to become ACTIVE per provider and OpenstackConfig.",
+		},
+		[]string{"provider", "config"},
+	)
+	changeCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "multinic_change_cache_lookups_total",
+			Help: "Change detection lookups by result (hit: memory cache, inventory: inventory hash, miss: changed).",
+		},
+		[]string{"result"},
+	)
+	violaRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "multinic_viola_requests_total",
+			Help: "Viola API requests by method and HTTP status code (error: transport failure).",
+		},
+		[]string{"method", "code"},
+	)
+	httpConnectionsOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "multinic_http_connections_open",
+			Help: "Open connections of the shared HTTP transports per service (contrabass, openstack, viola).",
+		},
+		[]string{"service"},
+	)
+	httpConnectionsAcquired = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "multinic_http_connections_acquired_total",
+			Help: "Connections obtained for requests per service and whether an idle pooled connection was reused.",
+		},
+		[]string{"service", "reused"},
+	)
+	httpTransportPools = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "multinic_http_transport_pools",
+			Help: "Number of shared HTTP transports (distinct service/TLS/timeout settings).",
+		},
+	)
+	notificationsReceived = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "multinic_notifications_total",
+			Help: "OpenStack notifications received by event type and result (enqueued, ignored).",
+		},
+		[]string{"event", "result"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		syncStageDuration,
+		syncStageErrors,
+		managedNodes,
+		managedInterfaces,
+		downPortsOutstanding,
+		changeCacheLookups,
+		violaRequests,
+		httpConnectionsOpen,
+		httpConnectionsAcquired,
+		httpTransportPools,
+		notificationsReceived,
+	)
+}
+
+// observeStage는 단계별 소요 시간과 오류 여부를 기록한다.
+func observeStage(stage string, start time.Time, err error) {
+	syncStageDuration.WithLabelValues(stage).Observe(time.Since(start).Seconds())
+	if err != nil {
+		syncStageErrors.WithLabelValues(stage).Inc()
+	}
+}
+
+// observeViolaResponse는 Viola 응답 코드를 기록한다. (viola.WithResponseObserver용)
+func observeViolaResponse(method string, statusCode int) {
+	code := "error"
+	if statusCode > 0 {
+		code = strconv.Itoa(statusCode)
+	}
+	violaRequests.WithLabelValues(method, code).Inc()
+}
+
+// poolMetrics는 공유 transport 연결 풀 상태를 기록한다. (httptransport.Observer 구현)
+type poolMetrics struct{}
+
+func (poolMetrics) ConnOpened(service string) {
+	httpConnectionsOpen.WithLabelValues(service).Inc()
+}
+
+func (poolMetrics) ConnClosed(service string) {
+	httpConnectionsOpen.WithLabelValues(service).Dec()
+}
+
+func (poolMetrics) ConnAcquired(service string, reused bool) {
+	httpConnectionsAcquired.WithLabelValues(service, strconv.FormatBool(reused)).Inc()
+}
+
+func (poolMetrics) PoolsChanged(count int) {
+	httpTransportPools.Set(float64(count))
+}
+
+// setManagedMetrics는 CR별 관리 노드/인터페이스/DOWN 포트 수를 기록한다.
+func setManagedMetrics(providerID, config string, nodes []viola.NodeConfig, downPorts int) {
+	interfaces := 0
+	for _, node := range nodes {
+		interfaces += len(node.Interfaces)
+	}
+	managedNodes.WithLabelValues(providerID, config).Set(float64(len(nodes)))
+	managedInterfaces.WithLabelValues(providerID, config).Set(float64(interfaces))
+	downPortsOutstanding.WithLabelValues(providerID, config).Set(float64(downPorts))
+}
+
+// forgetManagedMetrics는 CR 삭제 시 해당 CR의 gauge 시계열을 제거한다.
+func forgetManagedMetrics(providerID, config string) {
+	managedNodes.DeleteLabelValues(providerID, config)
+	managedInterfaces.DeleteLabelValues(providerID, config)
+	downPortsOutstanding.DeleteLabelValues(providerID, config)
+}
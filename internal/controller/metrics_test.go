@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"multinic-operator/pkg/viola"
+)
+
+func TestSetManagedMetrics(t *testing.T) {
+	nodes := []viola.NodeConfig{
+		{NodeName: "infra01", Interfaces: []viola.NodeInterface{{PortID: "p1"}, {PortID: "p2"}}},
+		{NodeName: "infra02", Interfaces: []viola.NodeInterface{{PortID: "p3"}}},
+	}
+	setManagedMetrics("provider-1", "default/cfg", nodes, 1)
+	if got := testutil.ToFloat64(managedNodes.WithLabelValues("provider-1", "default/cfg")); got != 2 {
+		t.Fatalf("expected 2 managed nodes, got %v", got)
+	}
+	if got := testutil.ToFloat64(managedInterfaces.WithLabelValues("provider-1", "default/cfg")); got != 3 {
+		t.Fatalf("expected 3 managed interfaces, got %v", got)
+	}
+	if got := testutil.ToFloat64(downPortsOutstanding.WithLabelValues("provider-1", "default/cfg")); got != 1 {
+		t.Fatalf("expected 1 down port, got %v", got)
+	}
+
+	forgetManagedMetrics("provider-1", "default/cfg")
+	if got := testutil.CollectAndCount(managedNodes); got != 0 {
+		t.Fatalf("expected managed node series to be removed, got %d", got)
+	}
+}
+
+func TestObserveViolaResponse(t *testing.T) {
+	before := testutil.ToFloat64(violaRequests.WithLabelValues("POST", "error"))
+	observeViolaResponse("POST", 0)
+	observeViolaResponse("POST", 502)
+	if got := testutil.ToFloat64(violaRequests.WithLabelValues("POST", "error")); got != before+1 {
+		t.Fatalf("expected transport error to be counted, got %v", got)
+	}
+	if got := testutil.ToFloat64(violaRequests.WithLabelValues("POST", "502")); got < 1 {
+		t.Fatalf("expected 502 response to be counted, got %v", got)
+	}
+}
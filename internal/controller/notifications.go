@@ -0,0 +1,123 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+	"multinic-operator/pkg/contrabass"
+	"multinic-operator/pkg/notification"
+)
+
+// notificationEventBuffer는 알림으로 인한 재조정 요청 대기열 크기이다.
+// 가득 차면 요청을 버리며, 누락분은 주기적 폴링으로 보완된다.
+const notificationEventBuffer = 256
+
+// notificationListTimeout은 알림 수신 시 OpenstackConfig 목록 조회 타임아웃이다.
+const notificationListTimeout = 10 * time.Second
+
+// ensureNotifications는 provider의 RabbitMQ 알림 구독에 CR을 등록한다.
+// RabbitMQ 정보가 없으면 등록을 해제하고 폴링만 사용한다.
+func (r *OpenstackConfigReconciler) ensureNotifications(owner, providerID string, provider *contrabass.Provider) {
+	if r.Notifications == nil {
+		return
+	}
+	if len(provider.RabbitURLs) == 0 {
+		r.Notifications.Release(owner)
+		return
+	}
+	r.Notifications.Ensure(owner, providerID, notification.Config{
+		URLs:     provider.RabbitURLs,
+		User:     provider.RabbitUser,
+		Password: provider.RabbitPass,
+	})
+}
+
+// handleNotification은 알림과 관련된 OpenstackConfig를 즉시 재조정 대기열에 넣는다.
+func (r *OpenstackConfigReconciler) handleNotification(providerID string, ev notification.Event) {
+	log := logf.Log.WithName("notification").WithValues("providerID", providerID, "event", ev.Type)
+	ctx, cancel := context.WithTimeout(context.Background(), notificationListTimeout)
+	defer cancel()
+
+	var list multinicv1alpha1.OpenstackConfigList
+	if err := r.List(ctx, &list); err != nil {
+		log.Error(err, "failed to list openstackconfigs for notification")
+		return
+	}
+	matched := 0
+	for i := range list.Items {
+		cfg := &list.Items[i]
+		if !notificationMatches(cfg, providerID, ev) {
+			continue
+		}
+		select {
+		case r.notifyEvents <- event.GenericEvent{Object: cfg}:
+			matched++
+		default:
+			log.V(1).Info("notification queue full; relying on polling", "openstackconfig", cfg.Namespace+"/"+cfg.Name)
+		}
+	}
+	result := "ignored"
+	if matched > 0 {
+		result = "enqueued"
+		log.V(1).Info("enqueued openstackconfigs from notification", "count", matched, "vmID", ev.VMID, "portID", ev.PortID)
+	}
+	notificationsReceived.WithLabelValues(ev.Type, result).Inc()
+}
+
+// notificationMatches는 알림이 CR의 대상 VM/포트와 관련 있는지 판단한다.
+// vmSelector/nodeDiscovery는 새 VM이 대상이 될 수 있으므로 같은 프로젝트의 VM 이벤트를 모두 받는다.
+func notificationMatches(cfg *multinicv1alpha1.OpenstackConfig, providerID string, ev notification.Event) bool {
+	creds := cfg.Spec.Credentials
+	if strings.TrimSpace(creds.OpenstackProviderID) != providerID || !cfg.DeletionTimestamp.IsZero() {
+		return false
+	}
+	if ev.ProjectID != "" && ev.ProjectID != strings.TrimSpace(creds.ProjectID) {
+		return false
+	}
+	if ev.VMID != "" {
+		if containsString(cfg.Spec.VmNames, ev.VMID) || containsString(cfg.Status.ResolvedVMIDs, ev.VMID) {
+			return true
+		}
+		if cfg.Spec.VMSelector != nil || cfg.Spec.NodeDiscovery != nil {
+			return true
+		}
+	}
+	if ev.PortID != "" {
+		for _, node := range cfg.Status.Nodes {
+			if containsString(node.PortIDs, ev.PortID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if strings.TrimSpace(v) == target {
+			return true
+		}
+	}
+	return false
+}
@@ -21,8 +21,10 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -33,16 +35,29 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	multinicv1alpha1 "multinic-operator/api/v1alpha1"
 	"multinic-operator/internal/inventory"
+	"multinic-operator/pkg/apierror"
 	"multinic-operator/pkg/contrabass"
+	"multinic-operator/pkg/httpretry"
+	"multinic-operator/pkg/httptransport"
+	"multinic-operator/pkg/notification"
 	"multinic-operator/pkg/openstack"
 	"multinic-operator/pkg/viola"
 )
@@ -50,25 +65,40 @@ import (
 // OpenstackConfigReconciler reconciles a OpenstackConfig object
 type OpenstackConfigReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
-	Log    logr.Logger
+	Scheme   *runtime.Scheme
+	Log      logr.Logger
+	Recorder record.EventRecorder
 
-	Inventory *inventory.Store
+	Inventory inventory.Backend
 
 	ViolaEndpoint    string
 	ViolaTimeout     time.Duration
 	ViolaInsecureTLS bool
 
-	cacheMu sync.RWMutex
-	cache   map[string]cacheEntry
+	// ProviderCacheTTL은 Contrabass provider 조회 결과 재사용 시간이다. (0이면 매번 조회)
+	ProviderCacheTTL time.Duration
 
-	pollMu     sync.RWMutex
-	lastChange map[string]time.Time
+	// Notifications는 OpenStack 알림(RabbitMQ) 구독기이다. (nil이면 폴링만 사용)
+	Notifications *notification.Listener
+	notifyEvents  chan event.GenericEvent
+
+	cacheMu    sync.RWMutex
+	cache      map[string]cacheEntry
+	tokens     *openstack.TokenCache
+	providers  *contrabass.ProviderCache
+	transports *httptransport.Registry
+
+	pollMu       sync.RWMutex
+	lastChange   map[string]time.Time
+	reportedSkip map[string]string
+	// emptyDiscovery는 vmSelector/nodeDiscovery가 VM을 하나도 찾지 못한 CR이다. (연속 두 번째 폴링에서만 노드 삭제)
+	emptyDiscovery map[string]bool
 }
 
 type cacheEntry struct {
-	hash string
-	node viola.NodeConfig
+	hash  string
+	owner string
+	node  viola.NodeConfig
 }
 
 type subnetFilter struct {
@@ -81,18 +111,39 @@ type subnetFilter struct {
 
 const maxInterfacesPerNode = 10
 
+// bizClusterTimeout은 biz 클러스터 Node 조회 요청의 타임아웃이다.
+const bizClusterTimeout = 30 * time.Second
+
+// maxEventNames는 이벤트 메시지에 나열할 노드/포트 이름의 최대 개수이다.
+const maxEventNames = 10
+
+// maxEventDiffLen은 이벤트 메시지에 붙일 노드별 변경 요약의 최대 길이(문자 수)이다.
+const maxEventDiffLen = 120
+
+// novaBulkMinVMs 이상이면 Nova 서버를 uuid 필터로 일괄 조회한다.
+const novaBulkMinVMs = 2
+
+// novaLookupConcurrency는 Nova 개별 조회(GetServer)의 최대 병렬 수이다.
+const novaLookupConcurrency = 8
+
+// openstackConfigFinalizer는 CR 삭제 전에 Viola/Inventory 정리를 보장한다.
+const openstackConfigFinalizer = "multinic.example.com/finalizer"
+
 type resolvedSettings struct {
 	contrabassEndpoint    string
 	contrabassEncryptKey  string
 	contrabassTimeout     time.Duration
 	contrabassInsecureTLS bool
+	contrabassTLS         tlsMaterial
 
 	violaEndpoint    string
 	violaTimeout     time.Duration
 	violaInsecureTLS bool
+	violaTLS         tlsMaterial
 
 	openstackTimeout             time.Duration
 	openstackInsecureTLS         bool
+	openstackTLS                 tlsMaterial
 	openstackNeutronEndpoint     string
 	openstackNovaEndpoint        string
 	openstackEndpointInterface   string
@@ -105,12 +156,20 @@ type resolvedSettings struct {
 	pollSlow       time.Duration
 	pollError      time.Duration
 	pollFastWindow time.Duration
+
+	// API별 재시도 정책 (서비스별 설정이 없으면 httpRetry* 공통 값)
+	contrabassRetry httpretry.Policy
+	openstackRetry  httpretry.Policy
+	violaRetry      httpretry.Policy
 }
 
 // +kubebuilder:rbac:groups=multinic.example.com,resources=openstackconfigs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=multinic.example.com,resources=openstackconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=multinic.example.com,resources=openstackconfigs/finalizers,verbs=update
+// +kubebuilder:rbac:groups=multinic.example.com,resources=openstackoperatorconfigs,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile은 OpenstackConfig를 기준으로 포트 수집/필터링/전송과 상태 갱신을 수행한다.
 func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -123,6 +182,16 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if !cfg.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, log, &cfg)
+	}
+	if !controllerutil.ContainsFinalizer(&cfg, openstackConfigFinalizer) {
+		controllerutil.AddFinalizer(&cfg, openstackConfigFinalizer)
+		if err := r.Update(ctx, &cfg); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
 	settings, err := r.resolveSettings(ctx, &cfg)
 	if err != nil {
 		log.Error(err, "invalid config")
@@ -164,21 +233,111 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	downPortFastMax := settings.downPortFastRetryMax
 
 	// 1) Contrabass provider lookup
-	cbClient := contrabass.NewClient(cbEndpoint, cbEncKey, cbTimeout, contrabass.WithInsecureTLS(cbInsecure))
-	provider, err := cbClient.GetProvider(ctx, cfg.Spec.Credentials.OpenstackProviderID)
+	cbClient := contrabass.NewClient(cbEndpoint, cbEncKey, cbTimeout, contrabass.WithTransport(r.sharedTransport(transportContrabass, settings.contrabassTLS, cbInsecure, cbTimeout)), contrabass.WithRetry(settings.contrabassRetry))
+	stageStart := time.Now()
+	provider, rotated, err := r.providers.GetProvider(ctx, cbClient, cfg.Spec.Credentials.OpenstackProviderID)
+	observeStage(stageContrabass, stageStart, err)
 	if err != nil {
 		log.Error(err, "failed to fetch provider from contrabass")
-		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "ContrabassError", err.Error())
-		return ctrl.Result{RequeueAfter: pollError}, nil
+		return r.apiFailure(ctx, log, &cfg, "ContrabassError", err, pollError, pollSlow), nil
 	}
 	// 2) Keystone token
-	ks := openstack.NewKeystoneClient(provider.KeystoneURL, provider.Domain, osTimeout, openstack.WithKeystoneInsecureTLS(osInsecure))
-	token, catalog, err := ks.AuthTokenWithCatalog(ctx, provider.AdminID, provider.AdminPass, cfg.Spec.Credentials.ProjectID)
+	osTransport := r.sharedTransport(transportOpenstack, settings.openstackTLS, osInsecure, osTimeout)
+	ks := openstack.NewKeystoneClient(provider.KeystoneURL, provider.Domain, osTimeout, openstack.WithKeystoneTransport(osTransport), openstack.WithKeystoneRetry(settings.openstackRetry))
+	if rotated {
+		// adminPw가 바뀌었으면 기존 토큰을 버리고 새 자격증명으로 재발급한다.
+		log.Info("contrabass provider credentials rotated; reissuing keystone token", "providerID", cfg.Spec.Credentials.OpenstackProviderID)
+		r.tokens.InvalidateUser(ks, provider.AdminID)
+		r.recordEvent(&cfg, corev1.EventTypeNormal, "CredentialsRotated", "contrabass provider %s credentials changed; keystone token reissued", cfg.Spec.Credentials.OpenstackProviderID)
+	}
+	r.ensureNotifications(stateKey, cfg.Spec.Credentials.OpenstackProviderID, provider)
+	stageStart = time.Now()
+	// 토큰은 만료 직전까지 재사용하고, 401이면 폐기 후 한 번만 재발급해 재시도한다.
+	osToken, err := r.tokens.Token(ctx, ks, provider.AdminID, provider.AdminPass, cfg.Spec.Credentials.ProjectID)
+	observeStage(stageKeystone, stageStart, err)
 	if err != nil {
 		log.Error(err, "failed to get keystone token")
-		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "KeystoneError", err.Error())
-		return ctrl.Result{RequeueAfter: pollError}, nil
+		return r.apiFailure(ctx, log, &cfg, "KeystoneError", err, pollError, pollSlow), nil
+	}
+	catalog := osToken.Catalog
+	auth := func(fn func(token string) error) error {
+		return r.tokens.WithRetry(ctx, ks, provider.AdminID, provider.AdminPass, cfg.Spec.Credentials.ProjectID, fn)
+	}
+
+	// 2-1) Resolve target VM IDs (vmNames + vmSelector + nodeDiscovery)
+	novaEndpoint := strings.TrimRight(novaOverride, "/")
+	if novaEndpoint == "" {
+		novaEndpoint = openstack.FindEndpoint(catalog, "compute", endpointIface, endpointRegion)
+	}
+	var nova *openstack.NovaClient
+	if novaEndpoint != "" {
+		nova = openstack.NewNovaClient(novaEndpoint, osTimeout, openstack.WithNovaTransport(osTransport), openstack.WithNovaRetry(settings.openstackRetry))
+	}
+	vmIDs := uniqueTrimmedList(cfg.Spec.VmNames)
+	vmIDToNodeName := map[string]string{}
+	selectorVMs, discoveredVMs := 0, 0
+	if len(vmIDs) == 0 && cfg.Spec.VMSelector == nil && cfg.Spec.NodeDiscovery == nil {
+		err := fmt.Errorf("vmNames, vmSelector or nodeDiscovery is required")
+		log.Error(err, "invalid config")
+		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "ConfigError", err.Error())
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+	}
+	if cfg.Spec.VMSelector != nil {
+		selector, err := compileVMSelector(cfg.Spec.VMSelector)
+		if err != nil {
+			log.Error(err, "invalid config")
+			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "ConfigError", err.Error())
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+		if nova == nil {
+			err := fmt.Errorf("nova endpoint not found; vmSelector requires nova")
+			log.Error(err, "failed to resolve nova endpoint from catalog", "interface", endpointIface, "region", endpointRegion)
+			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "NovaEndpointError", err.Error())
+			return ctrl.Result{RequeueAfter: pollError}, nil
+		}
+		stageStart = time.Now()
+		servers, err := callWithToken(auth, func(token string) ([]openstack.Server, error) {
+			return nova.ListServers(ctx, token, selector.tags)
+		})
+		observeStage(stageNova, stageStart, err)
+		if err != nil {
+			log.Error(err, "failed to list nova servers for vmSelector")
+			return r.apiFailure(ctx, log, &cfg, "NovaServerListError", err, pollError, pollSlow), nil
+		}
+		for _, server := range servers {
+			if !selector.matches(server) {
+				continue
+			}
+			vmIDs = append(vmIDs, server.ID)
+			vmIDToNodeName[server.ID] = nodeNameFromServer(server, nodeNameMetadataKey)
+		}
+		selectorVMs = len(vmIDToNodeName)
 	}
+	if cfg.Spec.NodeDiscovery != nil {
+		ref := cfg.Spec.NodeDiscovery.KubeconfigSecretRef
+		kubeconfig, err := r.readSecretKey(ctx, cfg.Namespace, strings.TrimSpace(ref.Name), strings.TrimSpace(ref.Key))
+		if err != nil {
+			err = fmt.Errorf("nodeDiscovery.kubeconfigSecretRef: %w", err)
+			log.Error(err, "invalid config")
+			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "ConfigError", err.Error())
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+		nodes, err := listBizClusterNodes(ctx, []byte(kubeconfig), cfg.Spec.NodeDiscovery.NodeSelector)
+		if err != nil {
+			log.Error(err, "failed to list biz cluster nodes")
+			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "NodeDiscoveryError", err.Error())
+			return ctrl.Result{RequeueAfter: pollError}, nil
+		}
+		// Node 이름이 실제 nodeName이므로 Nova 기반 매핑보다 우선한다.
+		discovered, names := vmIDsFromNodes(log, nodes)
+		discoveredVMs = len(discovered)
+		vmIDs = append(vmIDs, discovered...)
+		for vmID, nodeName := range names {
+			vmIDToNodeName[vmID] = nodeName
+		}
+	}
+	vmIDs = uniqueList(vmIDs)
+	r.updateResolvedVMsStatus(ctx, log, &cfg, vmIDs)
 
 	// 3) Neutron ports for the given VM IDs (device_id)
 	neutronEndpoint := neutronOverride
@@ -199,15 +358,24 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{RequeueAfter: pollError}, nil
 	}
 
-	neutron := openstack.NewNeutronClient(neutronEndpoint, osTimeout, openstack.WithNeutronInsecureTLS(osInsecure))
-	ports, err := neutron.ListPorts(ctx, token, cfg.Spec.Credentials.ProjectID, cfg.Spec.VmNames)
-	if err != nil {
-		log.Error(err, "failed to list neutron ports")
-		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "NeutronPortError", err.Error())
-		return ctrl.Result{RequeueAfter: pollError}, nil
+	neutron := openstack.NewNeutronClient(neutronEndpoint, osTimeout, openstack.WithNeutronTransport(osTransport), openstack.WithNeutronRetry(settings.openstackRetry))
+	var ports []openstack.Port
+	if len(vmIDs) > 0 {
+		stageStart = time.Now()
+		ports, err = callWithToken(auth, func(token string) ([]openstack.Port, error) {
+			return neutron.ListPorts(ctx, token, cfg.Spec.Credentials.ProjectID, vmIDs)
+		})
+		observeStage(stageNeutron, stageStart, err)
+		if err != nil {
+			log.Error(err, "failed to list neutron ports")
+			return r.apiFailure(ctx, log, &cfg, "NeutronPortError", err, pollError, pollSlow), nil
+		}
 	}
-	ports = filterPortsByStatus(log, ports, allowedPortStatuses)
-	ports = filterPortsByCreatedAfter(log, ports, cfg.CreationTimestamp.Time)
+	statusFiltered := filterPortsByStatus(log, ports, allowedPortStatuses)
+	baselineFiltered := filterPortsByCreatedAfter(log, statusFiltered, cfg.CreationTimestamp.Time)
+	r.recordSkippedPorts(&cfg, stateKey, "PortsSkippedByStatus", "not in allowed statuses", skippedPortIDs(ports, statusFiltered))
+	r.recordSkippedPorts(&cfg, stateKey, "PortsSkippedBeforeBaseline", "created before baseline", skippedPortIDs(statusFiltered, baselineFiltered))
+	ports = baselineFiltered
 
 	// 4) Resolve subnet CIDR/MTU (subnetIDs > subnetID > subnetName)
 	var filters []subnetFilter
@@ -226,15 +394,22 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		networkMTU := make(map[string]int)
 		for _, id := range subnetIDs {
-			subnet, err := neutron.GetSubnet(ctx, token, id)
+			stageStart = time.Now()
+			subnet, err := callWithToken(auth, func(token string) (openstack.Subnet, error) {
+				return neutron.GetSubnet(ctx, token, id)
+			})
+			observeStage(stageNeutron, stageStart, err)
 			if err != nil {
 				log.Error(err, "failed to get neutron subnet", "subnetID", id)
-				r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "NeutronSubnetError", err.Error())
-				return ctrl.Result{RequeueAfter: pollError}, nil
+				return r.apiFailure(ctx, log, &cfg, "NeutronSubnetError", err, pollError, pollSlow), nil
 			}
 			mtu, ok := networkMTU[subnet.NetworkID]
 			if !ok {
-				network, err := neutron.GetNetwork(ctx, token, subnet.NetworkID)
+				stageStart = time.Now()
+				network, err := callWithToken(auth, func(token string) (openstack.Network, error) {
+					return neutron.GetNetwork(ctx, token, subnet.NetworkID)
+				})
+				observeStage(stageNeutron, stageStart, err)
 				if err != nil {
 					log.Error(err, "failed to get neutron network; MTU will be omitted", "networkID", subnet.NetworkID)
 				} else {
@@ -251,17 +426,24 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			})
 		}
 	} else if subnetID != "" {
-		subnet, err := neutron.GetSubnet(ctx, token, subnetID)
+		stageStart = time.Now()
+		subnet, err := callWithToken(auth, func(token string) (openstack.Subnet, error) {
+			return neutron.GetSubnet(ctx, token, subnetID)
+		})
+		observeStage(stageNeutron, stageStart, err)
 		if err != nil {
 			log.Error(err, "failed to get neutron subnet", "subnetID", subnetID)
-			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "NeutronSubnetError", err.Error())
-			return ctrl.Result{RequeueAfter: pollError}, nil
+			return r.apiFailure(ctx, log, &cfg, "NeutronSubnetError", err, pollError, pollSlow), nil
 		}
 		if subnetName != "" && subnet.Name != subnetName {
 			log.Info("subnetID overrides subnetName", "subnetID", subnetID, "subnetName", subnetName, "resolvedName", subnet.Name)
 		}
 		mtu := 0
-		network, err := neutron.GetNetwork(ctx, token, subnet.NetworkID)
+		stageStart = time.Now()
+		network, err := callWithToken(auth, func(token string) (openstack.Network, error) {
+			return neutron.GetNetwork(ctx, token, subnet.NetworkID)
+		})
+		observeStage(stageNeutron, stageStart, err)
 		if err != nil {
 			log.Error(err, "failed to get neutron network; MTU will be omitted", "networkID", subnet.NetworkID)
 		} else {
@@ -275,11 +457,14 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 			Order:     0,
 		})
 	} else if subnetName != "" {
-		subnets, err := neutron.ListSubnets(ctx, token, cfg.Spec.Credentials.ProjectID, subnetName)
+		stageStart = time.Now()
+		subnets, err := callWithToken(auth, func(token string) ([]openstack.Subnet, error) {
+			return neutron.ListSubnets(ctx, token, cfg.Spec.Credentials.ProjectID, subnetName)
+		})
+		observeStage(stageNeutron, stageStart, err)
 		if err != nil {
 			log.Error(err, "failed to list neutron subnets", "subnetName", subnetName)
-			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "NeutronSubnetError", err.Error())
-			return ctrl.Result{RequeueAfter: pollError}, nil
+			return r.apiFailure(ctx, log, &cfg, "NeutronSubnetError", err, pollError, pollSlow), nil
 		}
 		if len(subnets) == 0 {
 			err := fmt.Errorf("subnet not found")
@@ -295,7 +480,11 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		subnet := subnets[0]
 		mtu := 0
-		network, err := neutron.GetNetwork(ctx, token, subnet.NetworkID)
+		stageStart = time.Now()
+		network, err := callWithToken(auth, func(token string) (openstack.Network, error) {
+			return neutron.GetNetwork(ctx, token, subnet.NetworkID)
+		})
+		observeStage(stageNeutron, stageStart, err)
 		if err != nil {
 			log.Error(err, "failed to get neutron network; MTU will be omitted", "networkID", subnet.NetworkID)
 		} else {
@@ -311,21 +500,49 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	}
 
 	// 5) Resolve nodeName from Nova (metadata key > server name > vmID)
-	novaEndpoint := strings.TrimRight(novaOverride, "/")
-	if novaEndpoint == "" {
-		novaEndpoint = openstack.FindEndpoint(catalog, "compute", endpointIface, endpointRegion)
-	}
-	vmIDToNodeName := map[string]string{}
-	if novaEndpoint != "" {
-		nova := openstack.NewNovaClient(novaEndpoint, osTimeout, openstack.WithNovaInsecureTLS(osInsecure))
-		vmIDToNodeName = resolveNodeNames(ctx, log, nova, token, cfg.Spec.VmNames, nodeNameMetadataKey)
+	// vmSelector로 찾은 VM은 목록 조회 결과를 그대로 사용한다.
+	if nova != nil {
+		stageStart = time.Now()
+		unresolved := make([]string, 0, len(vmIDs))
+		for _, vmID := range vmIDs {
+			if _, ok := vmIDToNodeName[vmID]; !ok {
+				unresolved = append(unresolved, vmID)
+			}
+		}
+		resolved, err := resolveNodeNames(ctx, log, nova, auth, unresolved, nodeNameMetadataKey, osTimeout)
+		for vmID, nodeName := range resolved {
+			vmIDToNodeName[vmID] = nodeName
+		}
+		// 조회 실패 VM은 이전 nodeName 또는 VM ID로 대체하고 stage 오류로만 집계한다.
+		observeStage(stageNova, stageStart, err)
 	} else {
 		log.Info("nova endpoint not found; using vm id as node name")
 	}
+	// 마지막 전송 목록은 detach 판단과 Nova 조회 실패 시 nodeName 유지에 사용한다.
+	previous, prevErr := r.lastSentNodes(ctx, violaProviderID, stateKey)
+	if prevErr != nil {
+		log.Error(prevErr, "failed to load last sent node configs; detach handling skipped")
+	}
+	fillNodeNamesFromPrevious(vmIDToNodeName, vmIDs, previous)
 
 	// 6) Map to node configs
-	nodes, downNodes, downPortIDs := mapPortsToNodes(cfg.Spec.VmNames, vmIDToNodeName, ports, filters, maxInterfacesPerNode)
-	nodes = filterNodesWithInterfaces(log, nodes)
+	mapped, downNodes, downPortIDs := mapPortsToNodes(vmIDs, vmIDToNodeName, ports, filters, maxInterfacesPerNode)
+	nodeStatuses := buildNodeStatuses(mapped, vmIDToNodeName, cfg.Status.Nodes)
+	nodes := filterNodesWithInterfaces(log, mapped)
+	setManagedMetrics(violaProviderID, stateKey, nodes, len(downPortIDs))
+	var removedNodes []viola.NodeRef
+	if prevErr == nil {
+		removedNodes = diffRemovedNodes(previous, nodes)
+	}
+	// tag/metadata 일시 변경이나 빈 Node 목록 등으로 동적 소스 결과가 비면 이전 노드가 모두 삭제되므로,
+	// 연속 두 번째 폴링에서도 비어 있을 때만 삭제한다.
+	emptySources := emptyDiscoverySources(&cfg.Spec, selectorVMs, discoveredVMs)
+	discoveryHold := ""
+	if !r.confirmDiscoveryEmpty(stateKey, len(emptySources) > 0 && len(removedNodes) > 0) {
+		discoveryHold = fmt.Sprintf("%s found no VMs; keeping %d previously sent node(s) until the next poll confirms", strings.Join(emptySources, ", "), len(removedNodes))
+		log.Info("VM discovery found no VMs; deferring node removal", "sources", emptySources, "nodes", nodeRefNames(removedNodes))
+		removedNodes = nil
+	}
 	downPortHash := hashDownPorts(downPortIDs)
 	now := time.Now()
 	retryDue, retryWait := shouldRetryDownPorts(cfg.Status.DownPortRetry, downPortHash, now, pollFast, pollSlow, downPortFastMax)
@@ -333,19 +550,29 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		r.updateDownPortRetryStatus(ctx, log, &cfg, nil)
 	}
 
-	nodesToSend, hashes := r.filterChanged(ctx, log, violaProviderID, nodes)
+	nodesToSend, hashes := r.filterChanged(ctx, log, violaProviderID, stateKey, nodes)
 	if downPortHash != "" && (retryDue || len(nodesToSend) > 0) {
 		downNodesToSend := selectNodesByName(nodes, downNodes)
 		nodesToSend, hashes = mergeNodesToSend(nodesToSend, hashes, downNodesToSend)
 	}
-	if len(nodesToSend) == 0 {
+	changes := nodeConfigDiffs(previous, nodesToSend)
+	for _, diff := range changes {
+		log.Info("node config changed", "node", diff.NodeName, "changes", diff.String())
+	}
+	if len(nodesToSend) == 0 && len(removedNodes) == 0 {
 		log.V(1).Info("no changes detected; skipping viola post")
-		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionTrue, "NoChange", "no changes detected")
 		lastChange, _ := r.getLastChange(stateKey)
 		requeue := adaptiveRequeue(now, false, lastChange, pollFastWindow, pollFast, pollSlow)
+		if discoveryHold != "" {
+			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "VMDiscoveryEmpty", discoveryHold)
+			requeue = min(requeue, pollFast)
+		} else {
+			r.setReadyCondition(ctx, log, &cfg, metav1.ConditionTrue, "NoChange", "no changes detected")
+		}
 		if downPortHash != "" && !retryDue && retryWait > 0 && retryWait < requeue {
 			requeue = retryWait
 		}
+		r.updateNodeStatuses(ctx, log, &cfg, nodeStatuses)
 		return ctrl.Result{RequeueAfter: requeue}, nil
 	}
 
@@ -353,28 +580,64 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	vi := viola.NewClient(
 		violaEndpoint,
 		violaTimeout,
-		viola.WithInsecureTLS(violaInsecure),
+		viola.WithTransport(r.sharedTransport(transportViola, settings.violaTLS, violaInsecure, violaTimeout)),
 		viola.WithProviderID(violaProviderID),
+		viola.WithResponseObserver(observeViolaResponse),
+		viola.WithRetry(settings.violaRetry),
 	)
-	if err := vi.SendNodeConfigs(ctx, nodesToSend); err != nil {
-		log.Error(err, "failed to send node configs to viola")
-		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "ViolaPostError", err.Error())
-		return ctrl.Result{RequeueAfter: pollError}, nil
+	if len(nodesToSend) > 0 {
+		// Viola apply는 인터페이스 목록 전체를 교체하므로 인터페이스 제거도 재전송으로 반영된다.
+		stageStart = time.Now()
+		err := vi.SendNodeConfigs(ctx, nodesToSend)
+		observeStage(stageViola, stageStart, err)
+		if err != nil {
+			log.Error(err, "failed to send node configs to viola")
+			applyNodeSendResult(nodeStatuses, nodeConfigVMIDs(nodesToSend), nil, err)
+			r.updateNodeStatuses(ctx, log, &cfg, nodeStatuses)
+			return r.apiFailure(ctx, log, &cfg, "ViolaPostError", err, pollError, pollSlow), nil
+		}
 	}
 
 	sendTime := time.Now()
+	applyNodeSendResult(nodeStatuses, nodeConfigVMIDs(nodesToSend), &sendTime, nil)
 	for _, node := range nodesToSend {
 		hash := hashes[node.NodeName]
-		r.setCache(violaProviderID, node.NodeName, cacheEntry{hash: hash, node: node})
+		r.setCache(violaProviderID, node.NodeName, cacheEntry{hash: hash, owner: stateKey, node: node})
 		if r.Inventory != nil {
-			if err := r.Inventory.Upsert(ctx, violaProviderID, node, hash, sendTime.UTC()); err != nil {
+			if err := r.Inventory.Upsert(ctx, violaProviderID, stateKey, node, hash, sendTime.UTC()); err != nil {
 				log.Error(err, "inventory upsert failed", "node", node.NodeName)
 			}
 		}
 	}
 
-	log.Info("synced node configs to viola", "count", len(nodesToSend))
-	r.setReadyCondition(ctx, log, &cfg, metav1.ConditionTrue, "Synced", fmt.Sprintf("synced %d node(s)", len(nodesToSend)))
+	// 인터페이스가 모두 사라졌거나 대상에서 빠진 노드는 Viola에서 삭제한다.
+	if len(removedNodes) > 0 {
+		stageStart = time.Now()
+		err := vi.DeleteNodeConfigs(ctx, removedNodes)
+		observeStage(stageViola, stageStart, err)
+		if err != nil {
+			log.Error(err, "failed to delete removed node configs from viola")
+			nodeStatuses = applyNodeDeleteResult(nodeStatuses, removedNodes, err)
+			r.updateNodeStatuses(ctx, log, &cfg, nodeStatuses)
+			err = fmt.Errorf("delete node configs %s: %w", summarizeNames(nodeRefNames(removedNodes)), err)
+			return r.apiFailure(ctx, log, &cfg, "ViolaDeleteError", err, pollError, pollSlow), nil
+		}
+		r.markNodesRemoved(ctx, log, violaProviderID, removedNodes, sendTime)
+	}
+
+	r.updateNodeStatuses(ctx, log, &cfg, nodeStatuses)
+	if len(nodesToSend) > 0 {
+		r.recordEvent(&cfg, corev1.EventTypeNormal, "Synced", "sent %d node config(s) to viola: %s%s", len(nodesToSend), summarizeNames(nodeConfigNames(nodesToSend)), summarizeDiffs(changes))
+	}
+	if len(removedNodes) > 0 {
+		r.recordEvent(&cfg, corev1.EventTypeNormal, "NodesRemoved", "deleted %d node config(s) from viola: %s", len(removedNodes), summarizeNames(nodeRefNames(removedNodes)))
+	}
+	log.Info("synced node configs to viola", "count", len(nodesToSend), "removed", len(removedNodes))
+	if discoveryHold != "" {
+		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionFalse, "VMDiscoveryEmpty", discoveryHold)
+	} else {
+		r.setReadyCondition(ctx, log, &cfg, metav1.ConditionTrue, "Synced", fmt.Sprintf("synced %d node(s), removed %d node(s)", len(nodesToSend), len(removedNodes)))
+	}
 
 	// 변경 직후에는 빠르게 재조회하고, 안정 구간에서는 느리게 재조회한다.
 	r.recordChange(stateKey, sendTime)
@@ -390,10 +653,198 @@ func (r *OpenstackConfigReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 // 컨트롤러를 매니저에 등록한다.
 // SetupWithManager는 컨트롤러와 인덱서를 매니저에 등록한다.
 func (r *OpenstackConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &multinicv1alpha1.OpenstackConfig{}, secretRefIndex, indexSecretRefs); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &multinicv1alpha1.OpenstackConfig{}, configMapRefIndex, indexConfigMapRefs); err != nil {
+		return err
+	}
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&multinicv1alpha1.OpenstackConfig{}).
 		Named("openstackconfig").
-		Complete(r)
+		// encrypt key/kubeconfig Secret이 교체되면 참조하는 CR을 폴링 주기와 관계없이 재조정한다.
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.mapSecret), builder.WithPredicates(secretDataChanged())).
+		// CA bundle이 교체되면 새 인증서로 즉시 재조정한다.
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.mapConfigMap), builder.WithPredicates(configMapDataChanged()))
+	if r.Notifications != nil {
+		// 알림 수신 시 관련 CR을 폴링 주기와 관계없이 즉시 재조정한다.
+		r.notifyEvents = make(chan event.GenericEvent, notificationEventBuffer)
+		r.Notifications.SetHandler(r.handleNotification)
+		if err := mgr.Add(r.Notifications); err != nil {
+			return err
+		}
+		b = b.WatchesRawSource(source.Channel(r.notifyEvents, &handler.EnqueueRequestForObject{}))
+	}
+	// 오퍼레이터 기본값이 바뀌면 이를 참조하는 CR을 재조정한다.
+	b = b.Watches(&multinicv1alpha1.OpenstackOperatorConfig{}, handler.EnqueueRequestsFromMapFunc(r.mapOperatorConfig))
+	return b.Complete(r)
+}
+
+// reconcileDelete는 CR 삭제 시 이 CR이 전송한 NodeConfig를 Viola에서 삭제하고,
+// Inventory/캐시를 정리한 뒤 finalizer를 해제한다.
+func (r *OpenstackConfigReconciler) reconcileDelete(ctx context.Context, log logr.Logger, cfg *multinicv1alpha1.OpenstackConfig) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cfg, openstackConfigFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	owner := cfg.Namespace + "/" + cfg.Name
+	violaProviderID := strings.TrimSpace(cfg.Spec.Credentials.K8sProviderID)
+
+	var refs []viola.NodeRef
+	if violaProviderID != "" {
+		var err error
+		vmIDs := append(append([]string(nil), cfg.Spec.VmNames...), cfg.Status.ResolvedVMIDs...)
+		refs, err = r.ownedNodeRefs(ctx, violaProviderID, owner, vmIDs)
+		if err != nil {
+			log.Error(err, "failed to list owned node configs")
+			return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
+	teardownSkipped := false
+	if len(refs) > 0 {
+		spec := r.teardownSettings(ctx, log, cfg)
+		endpoint, timeout, insecure, err := r.resolveViolaSettings(spec)
+		if err != nil {
+			// Viola 주소를 알 수 없으면 정리할 방법이 없으므로 finalizer를 붙잡지 않는다.
+			// Viola에 남은 노드를 추적할 수 있도록 Inventory 레코드는 지우지 않는다.
+			log.Error(err, "viola endpoint unavailable; skipping node config teardown", "count", len(refs))
+			teardownSkipped = true
+			r.recordEvent(cfg, corev1.EventTypeWarning, "NodeConfigTeardownSkipped", "viola endpoint unavailable; %d node config(s) left in viola and kept in inventory: %s: %v", len(refs), summarizeNames(nodeRefNames(refs)), err)
+		} else {
+			violaTLS, tlsErr := r.resolveTLSConfig(ctx, cfg.Namespace, spec.ViolaTLS, "spec.settings.violaTLS")
+			if tlsErr != nil {
+				log.Error(tlsErr, "invalid viola TLS settings; using system CA")
+			}
+			vi := viola.NewClient(
+				endpoint,
+				timeout,
+				viola.WithTransport(r.sharedTransport(transportViola, violaTLS, insecure, timeout)),
+				viola.WithProviderID(violaProviderID),
+				viola.WithResponseObserver(observeViolaResponse),
+				viola.WithRetry(resolveHTTPRetryOrDefault(log, spec, spec.ViolaRetry, "spec.settings.violaRetry")),
+			)
+			stageStart := time.Now()
+			err := vi.DeleteNodeConfigs(ctx, refs)
+			observeStage(stageViola, stageStart, err)
+			if err != nil {
+				log.Error(err, "failed to delete node configs from viola")
+				r.setReadyCondition(ctx, log, cfg, metav1.ConditionFalse, apiErrorReason("ViolaDeleteError", err), err.Error())
+				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			r.purgeNodes(ctx, log, violaProviderID, refs)
+		}
+	}
+	r.forgetPollState(owner)
+	forgetManagedMetrics(violaProviderID, owner)
+	if r.Notifications != nil {
+		r.Notifications.Release(owner)
+	}
+	if len(refs) > 0 && !teardownSkipped {
+		r.recordEvent(cfg, corev1.EventTypeNormal, "NodeConfigsDeleted", "deleted %d node config(s) on teardown: %s", len(refs), summarizeNames(nodeRefNames(refs)))
+	}
+
+	controllerutil.RemoveFinalizer(cfg, openstackConfigFinalizer)
+	if err := r.Update(ctx, cfg); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log.Info("released openstackconfig", "deleted", len(refs))
+	return ctrl.Result{}, nil
+}
+
+// ownedNodeRefs는 캐시/Inventory에서 owner가 전송한 노드 목록을 찾는다.
+// owner가 기록되지 않은 이전 레코드는 vmIDs(instanceId) 일치 여부로 판단한다.
+func (r *OpenstackConfigReconciler) ownedNodeRefs(ctx context.Context, providerID, owner string, vmIDs []string) ([]viola.NodeRef, error) {
+	vmSet := make(map[string]struct{}, len(vmIDs))
+	for _, id := range vmIDs {
+		vmSet[strings.TrimSpace(id)] = struct{}{}
+	}
+	seen := make(map[string]viola.NodeRef)
+	for nodeName, entry := range r.listCache(providerID) {
+		if entry.owner == owner {
+			seen[nodeName] = viola.NodeRef{NodeName: nodeName, InstanceID: entry.node.InstanceID}
+		}
+	}
+	if r.Inventory != nil {
+		records, err := r.Inventory.List(ctx, providerID, "", "")
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			if !recordOwnedBy(rec, owner, vmSet) {
+				continue
+			}
+			seen[rec.NodeName] = viola.NodeRef{NodeName: rec.NodeName, InstanceID: rec.InstanceID}
+		}
+	}
+	refs := make([]viola.NodeRef, 0, len(seen))
+	for _, ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].NodeName < refs[j].NodeName
+	})
+	return refs, nil
+}
+
+// recordOwnedBy는 Inventory 레코드가 owner CR 소유인지 판단한다.
+func recordOwnedBy(rec inventory.Record, owner string, vmSet map[string]struct{}) bool {
+	if rec.Owner != "" {
+		return rec.Owner == owner
+	}
+	_, ok := vmSet[rec.InstanceID]
+	return ok
+}
+
+// lastSentNodes는 owner가 마지막으로 전송한(삭제되지 않은) 노드 목록을 캐시와 Inventory에서 모은다.
+func (r *OpenstackConfigReconciler) lastSentNodes(ctx context.Context, providerID, owner string) (map[string]viola.NodeConfig, error) {
+	out := make(map[string]viola.NodeConfig)
+	for nodeName, entry := range r.listCache(providerID) {
+		if entry.owner == owner {
+			out[nodeName] = entry.node
+		}
+	}
+	if r.Inventory == nil {
+		return out, nil
+	}
+	records, err := r.Inventory.List(ctx, providerID, "", "")
+	if err != nil {
+		return nil, err
+	}
+	for _, rec := range records {
+		if rec.Owner != owner || rec.RemovedAt != nil {
+			continue
+		}
+		if _, ok := out[rec.NodeName]; !ok {
+			out[rec.NodeName] = rec.Config
+		}
+	}
+	return out, nil
+}
+
+// markNodesRemoved는 Viola에서 삭제한 노드를 캐시에서 지우고 Inventory에 삭제 표시를 남긴다.
+func (r *OpenstackConfigReconciler) markNodesRemoved(ctx context.Context, log logr.Logger, providerID string, refs []viola.NodeRef, at time.Time) {
+	for _, ref := range refs {
+		r.deleteCache(providerID, ref.NodeName)
+		if r.Inventory == nil {
+			continue
+		}
+		if err := r.Inventory.MarkRemoved(ctx, providerID, ref.NodeName, at.UTC()); err != nil {
+			log.Error(err, "inventory mark removed failed", "node", ref.NodeName)
+		}
+	}
+}
+
+// purgeNodes는 삭제된 노드의 캐시/Inventory 레코드를 제거한다.
+func (r *OpenstackConfigReconciler) purgeNodes(ctx context.Context, log logr.Logger, providerID string, refs []viola.NodeRef) {
+	for _, ref := range refs {
+		r.deleteCache(providerID, ref.NodeName)
+		if r.Inventory == nil {
+			continue
+		}
+		if err := r.Inventory.Delete(ctx, providerID, ref.NodeName); err != nil {
+			log.Error(err, "inventory delete failed", "node", ref.NodeName)
+		}
+	}
 }
 
 // mapPortsToNodes는 VM별 포트 목록을 Agent용 NodeConfig로 변환한다.
@@ -501,28 +952,298 @@ func mapPortsToNodes(vmIDs []string, vmIDToNodeName map[string]string, ports []o
 
 // resolveNodeNames는 VM ID 목록을 Nova에서 조회해 nodeName을 결정한다.
 // 우선순위: metadataKey(설정 시) > server name > vmID
-func resolveNodeNames(ctx context.Context, log logr.Logger, nova *openstack.NovaClient, token string, vmIDs []string, metadataKey string) map[string]string {
+// 먼저 /servers/detail?uuid=... 로 일괄 조회하고, 빠진 VM만 제한된 병렬도로 개별 조회한다.
+// 전체 조회는 timeout 안에서 끝나며, 시간 초과/취소 시 남은 VM은 조회하지 않는다.
+// 조회에 실패한 VM은 결과에서 빠지며, 호출 측에서 이전 nodeName 또는 vmID로 대체한다.
+// err는 실패한 개별 조회를 모은 오류이다. (삭제된 VM은 제외)
+func resolveNodeNames(ctx context.Context, log logr.Logger, nova *openstack.NovaClient, auth authCall, vmIDs []string, metadataKey string, timeout time.Duration) (map[string]string, error) {
+	vmIDs = uniqueList(vmIDs)
 	result := make(map[string]string, len(vmIDs))
-	for _, vmID := range uniqueList(vmIDs) {
-		server, err := nova.GetServer(ctx, token, vmID)
+	if len(vmIDs) == 0 {
+		return result, nil
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if len(vmIDs) >= novaBulkMinVMs {
+		servers, err := callWithToken(auth, func(token string) ([]openstack.Server, error) {
+			return nova.ListServersByIDs(ctx, token, vmIDs)
+		})
 		if err != nil {
-			log.Error(err, "failed to fetch nova server; fallback to vm id", "vmID", vmID)
-			result[vmID] = vmID
+			log.V(1).Info("nova bulk server lookup failed; falling back to per-server lookup", "error", err.Error())
+		}
+		for _, server := range servers {
+			result[server.ID] = nodeNameFromServer(server, metadataKey)
+		}
+	}
+
+	missing := make([]string, 0, len(vmIDs)-len(result))
+	for _, vmID := range vmIDs {
+		if _, ok := result[vmID]; !ok {
+			missing = append(missing, vmID)
+		}
+	}
+	found, err := getServersConcurrently(ctx, log, nova, auth, missing, metadataKey)
+	for vmID, nodeName := range found {
+		result[vmID] = nodeName
+	}
+	return result, err
+}
+
+// getServersConcurrently는 GetServer를 최대 novaLookupConcurrency개씩 병렬로 호출한다.
+func getServersConcurrently(ctx context.Context, log logr.Logger, nova *openstack.NovaClient, auth authCall, vmIDs []string, metadataKey string) (map[string]string, error) {
+	result := make(map[string]string, len(vmIDs))
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+		sem  = make(chan struct{}, novaLookupConcurrency)
+	)
+	for i, vmID := range vmIDs {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			log.Error(ctx.Err(), "nova server lookup aborted; fallback to last node name or vm id", "skipped", len(vmIDs)-i)
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("nova server lookup aborted with %d vm(s) left: %w", len(vmIDs)-i, ctx.Err()))
+			mu.Unlock()
+			break
+		}
+		wg.Add(1)
+		go func(vmID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			server, err := callWithToken(auth, func(token string) (openstack.Server, error) {
+				return nova.GetServer(ctx, token, vmID)
+			})
+			if errors.Is(err, apierror.ErrNotFound) {
+				// 삭제된 VM은 오류로 집계하지 않는다.
+				log.Info("nova server not found; fallback to last node name or vm id", "vmID", vmID)
+				return
+			}
+			if err != nil {
+				log.Error(err, "failed to fetch nova server; fallback to last node name or vm id", "vmID", vmID)
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("get nova server %s: %w", vmID, err))
+				mu.Unlock()
+				return
+			}
+			mu.Lock()
+			result[vmID] = nodeNameFromServer(server, metadataKey)
+			mu.Unlock()
+		}(vmID)
+	}
+	wg.Wait()
+	return result, errors.Join(errs...)
+}
+
+// authCall은 Keystone 토큰으로 OpenStack API를 호출한다. (401이면 토큰 재발급 후 1회 재시도)
+type authCall func(fn func(token string) error) error
+
+// callWithToken은 결과를 반환하는 OpenStack 호출을 authCall로 감싼다.
+func callWithToken[T any](auth authCall, fn func(token string) (T, error)) (T, error) {
+	var out T
+	err := auth(func(token string) error {
+		var err error
+		out, err = fn(token)
+		return err
+	})
+	return out, err
+}
+
+// nodeNameFromServer는 Nova 서버 정보에서 nodeName을 결정한다. (metadataKey > server name > server ID)
+func nodeNameFromServer(server openstack.Server, metadataKey string) string {
+	nodeName := ""
+	if metadataKey != "" {
+		nodeName = strings.TrimSpace(server.Metadata[metadataKey])
+	}
+	if nodeName == "" {
+		nodeName = strings.TrimSpace(server.Name)
+	}
+	if nodeName == "" {
+		nodeName = server.ID
+	}
+	return nodeName
+}
+
+// listBizClusterNodes는 kubeconfig로 biz 클러스터에 접속해 Node 목록을 조회한다.
+func listBizClusterNodes(ctx context.Context, kubeconfig []byte, nodeSelector map[string]string) ([]corev1.Node, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	restConfig.Timeout = bizClusterTimeout
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create biz cluster client: %w", err)
+	}
+	opts := metav1.ListOptions{}
+	if len(nodeSelector) > 0 {
+		opts.LabelSelector = labels.SelectorFromSet(nodeSelector).String()
+	}
+	list, err := clientset.CoreV1().Nodes().List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("list nodes: %w", err)
+	}
+	return list.Items, nil
+}
+
+// vmIDsFromNodes는 Node의 spec.providerID(openstack:///<uuid>)에서 VM ID와 nodeName을 추출한다.
+func vmIDsFromNodes(log logr.Logger, nodes []corev1.Node) ([]string, map[string]string) {
+	vmIDs := make([]string, 0, len(nodes))
+	names := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		vmID, ok := parseOpenstackProviderID(node.Spec.ProviderID)
+		if !ok {
+			log.Info("skip node without openstack providerID", "node", node.Name, "providerID", node.Spec.ProviderID)
 			continue
 		}
-		nodeName := ""
-		if metadataKey != "" {
-			nodeName = strings.TrimSpace(server.Metadata[metadataKey])
+		if prev, exists := names[vmID]; exists {
+			log.Info("skip node with duplicated providerID", "node", node.Name, "vmID", vmID, "existingNode", prev)
+			continue
 		}
-		if nodeName == "" {
-			nodeName = strings.TrimSpace(server.Name)
+		vmIDs = append(vmIDs, vmID)
+		names[vmID] = node.Name
+	}
+	sort.Strings(vmIDs)
+	return vmIDs, names
+}
+
+// parseOpenstackProviderID는 openstack:///<uuid> 또는 openstack://<region>/<uuid> 형식에서 VM ID를 추출한다.
+func parseOpenstackProviderID(providerID string) (string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(providerID), "openstack://")
+	if !ok {
+		return "", false
+	}
+	vmID := strings.TrimSpace(rest[strings.LastIndex(rest, "/")+1:])
+	if vmID == "" {
+		return "", false
+	}
+	return vmID, true
+}
+
+// vmSelector는 spec.vmSelector를 검증/컴파일한 결과이다.
+type vmSelector struct {
+	metadata  map[string]string
+	nameRegex *regexp.Regexp
+	tags      []string
+}
+
+// compileVMSelector는 vmSelector를 검증하고 이름 정규식을 컴파일한다.
+func compileVMSelector(spec *multinicv1alpha1.VMSelector) (*vmSelector, error) {
+	out := &vmSelector{
+		metadata: make(map[string]string, len(spec.MatchMetadata)),
+		tags:     uniqueTrimmedList(spec.Tags),
+	}
+	for k, v := range spec.MatchMetadata {
+		key := strings.TrimSpace(k)
+		if key == "" {
+			return nil, fmt.Errorf("vmSelector.matchMetadata has an empty key")
 		}
-		if nodeName == "" {
-			nodeName = vmID
+		out.metadata[key] = v
+	}
+	if pattern := strings.TrimSpace(spec.NameRegex); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vmSelector.nameRegex: %w", err)
+		}
+		out.nameRegex = re
+	}
+	if len(out.metadata) == 0 && out.nameRegex == nil && len(out.tags) == 0 {
+		return nil, fmt.Errorf("vmSelector requires matchMetadata, nameRegex or tags")
+	}
+	return out, nil
+}
+
+// matches는 서버가 selector 조건(metadata/이름/tags)을 모두 만족하는지 확인한다.
+// tags는 Nova에서 필터링하지만 microversion 미지원 환경을 위해 응답에서도 다시 확인한다.
+func (s *vmSelector) matches(server openstack.Server) bool {
+	for k, v := range s.metadata {
+		if got, ok := server.Metadata[k]; !ok || got != v {
+			return false
+		}
+	}
+	if s.nameRegex != nil && !s.nameRegex.MatchString(server.Name) {
+		return false
+	}
+	if len(s.tags) > 0 {
+		have := make(map[string]struct{}, len(server.Tags))
+		for _, tag := range server.Tags {
+			have[tag] = struct{}{}
+		}
+		for _, tag := range s.tags {
+			if _, ok := have[tag]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// fillNodeNamesFromPrevious는 nodeName을 확인하지 못한 VM에 마지막 전송 시 nodeName을 채운다.
+// Nova 일시 오류로 nodeName이 vmID로 바뀌어 기존 노드가 삭제되는 것을 막는다.
+func fillNodeNamesFromPrevious(vmIDToNodeName map[string]string, vmIDs []string, previous map[string]viola.NodeConfig) {
+	if len(previous) == 0 {
+		return
+	}
+	byInstance := make(map[string]string, len(previous))
+	for _, node := range previous {
+		if node.InstanceID != "" {
+			byInstance[node.InstanceID] = node.NodeName
+		}
+	}
+	for _, vmID := range vmIDs {
+		if strings.TrimSpace(vmIDToNodeName[vmID]) != "" {
+			continue
+		}
+		if name, ok := byInstance[vmID]; ok {
+			vmIDToNodeName[vmID] = name
+		}
+	}
+}
+
+// diffRemovedNodes는 마지막 전송 목록 중 현재 노드 집합에 없는 노드를 삭제 대상으로 반환한다.
+func diffRemovedNodes(previous map[string]viola.NodeConfig, current []viola.NodeConfig) []viola.NodeRef {
+	if len(previous) == 0 {
+		return nil
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, node := range current {
+		currentSet[node.NodeName] = struct{}{}
+	}
+	removed := make([]viola.NodeRef, 0)
+	for name, node := range previous {
+		if _, ok := currentSet[name]; ok {
+			continue
+		}
+		removed = append(removed, viola.NodeRef{NodeName: name, InstanceID: node.InstanceID})
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].NodeName < removed[j].NodeName
+	})
+	return removed
+}
+
+// nodeConfigDiffs는 재전송 대상 노드별로 마지막 전송 대비 인터페이스 변경을 계산한다.
+// 처음 전송하는 노드와 변경이 없는 노드(DOWN 포트 재시도 등)는 제외한다.
+func nodeConfigDiffs(previous map[string]viola.NodeConfig, nodes []viola.NodeConfig) []viola.NodeConfigDiff {
+	out := make([]viola.NodeConfigDiff, 0, len(nodes))
+	for _, node := range nodes {
+		prev, ok := previous[node.NodeName]
+		if !ok {
+			continue
+		}
+		if diff := viola.DiffNodeConfigs(prev, node); !diff.Empty() {
+			out = append(out, diff)
 		}
-		result[vmID] = nodeName
 	}
-	return result
+	sort.Slice(out, func(i, j int) bool { return out[i].NodeName < out[j].NodeName })
+	return out
 }
 
 func firstSubnet(fips []openstack.FixedIP) string {
@@ -611,7 +1332,7 @@ func resolveDuration(specValue, field string, def time.Duration) (time.Duration,
 }
 
 // resolveContrabassEncryptKey는 SecretRef → 기본 Secret → settings 순으로 키를 선택한다.
-func (r *OpenstackConfigReconciler) resolveContrabassEncryptKey(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig) (string, error) {
+func (r *OpenstackConfigReconciler) resolveContrabassEncryptKey(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig, spec *multinicv1alpha1.OpenstackConfigSettings) (string, error) {
 	if cfg.Spec.Secrets != nil && cfg.Spec.Secrets.ContrabassEncryptKeySecretRef != nil {
 		ref := cfg.Spec.Secrets.ContrabassEncryptKeySecretRef
 		name := strings.TrimSpace(ref.Name)
@@ -633,110 +1354,127 @@ func (r *OpenstackConfigReconciler) resolveContrabassEncryptKey(ctx context.Cont
 		}
 		return trimmed, nil
 	}
-	if value, err := r.readSecretKey(ctx, cfg.Namespace, "contrabass-encrypt-key", "CONTRABASS_ENCRYPT_KEY"); err == nil {
+	if value, err := r.readSecretKey(ctx, cfg.Namespace, multinicv1alpha1.DefaultContrabassEncryptKeySecretName, multinicv1alpha1.DefaultContrabassEncryptKeySecretKey); err == nil {
 		return value, nil
 	}
-	if cfg.Spec.Settings != nil {
-		if v := strings.TrimSpace(cfg.Spec.Settings.ContrabassEncryptKey); v != "" {
-			return v, nil
-		}
+	if v := strings.TrimSpace(spec.ContrabassEncryptKey); v != "" {
+		return v, nil
 	}
 	return "", fmt.Errorf("contrabassEncryptKey is required (set spec.secrets.contrabassEncryptKeySecretRef, create %s/contrabass-encrypt-key Secret, or set spec.settings.contrabassEncryptKey)", cfg.Namespace)
 }
 
-// resolveSettings는 CR settings를 해석해 런타임 설정값으로 변환한다.
+// resolveSettings는 CR settings를 오퍼레이터 기본값 위에 겹쳐 런타임 설정값으로 변환한다.
 func (r *OpenstackConfigReconciler) resolveSettings(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig) (resolvedSettings, error) {
 	var out resolvedSettings
-	spec := &multinicv1alpha1.OpenstackConfigSettings{}
-	if cfg.Spec.Settings != nil {
-		spec = cfg.Spec.Settings
+	spec, err := r.effectiveSettings(ctx, cfg)
+	if err != nil {
+		return out, err
 	}
 
 	cbEndpoint, err := resolveRequiredString(spec.ContrabassEndpoint, "spec.settings.contrabassEndpoint")
 	if err != nil {
 		return out, err
 	}
-	cbEncKey, err := r.resolveContrabassEncryptKey(ctx, cfg)
+	cbEncKey, err := r.resolveContrabassEncryptKey(ctx, cfg, spec)
 	if err != nil {
 		return out, err
 	}
-	cbTimeout, err := resolveDuration(spec.ContrabassTimeout, "spec.settings.contrabassTimeout", 30*time.Second)
+	cbTimeout, err := resolveDuration(spec.ContrabassTimeout, "spec.settings.contrabassTimeout", multinicv1alpha1.DefaultContrabassTimeout)
 	if err != nil {
 		return out, err
 	}
 	cbInsecure := resolveBool(spec.ContrabassInsecureTLS, false)
 
-	violaEndpoint := strings.TrimSpace(resolveString(spec.ViolaEndpoint, ""))
-	if violaEndpoint == "" {
-		violaEndpoint = strings.TrimSpace(r.ViolaEndpoint)
-	}
-	if violaEndpoint == "" {
-		return out, fmt.Errorf("violaEndpoint is required (set spec.settings.violaEndpoint or VIOLA_ENDPOINT)")
-	}
-	violaTimeout := r.ViolaTimeout
-	if violaTimeout <= 0 {
-		violaTimeout = 30 * time.Second
+	violaEndpoint, violaTimeout, violaInsecure, err := r.resolveViolaSettings(spec)
+	if err != nil {
+		return out, err
 	}
-	violaInsecure := r.ViolaInsecureTLS
 
-	osTimeout, err := resolveDuration(spec.OpenstackTimeout, "spec.settings.openstackTimeout", 30*time.Second)
+	osTimeout, err := resolveDuration(spec.OpenstackTimeout, "spec.settings.openstackTimeout", multinicv1alpha1.DefaultOpenstackTimeout)
 	if err != nil {
 		return out, err
 	}
 	osInsecure := resolveBool(spec.OpenstackInsecureTLS, false)
 	neutronOverride := resolveString(spec.OpenstackNeutronEndpoint, "")
 	novaOverride := resolveString(spec.OpenstackNovaEndpoint, "")
-	endpointIface := resolveString(spec.OpenstackEndpointInterface, "public")
+	endpointIface := resolveString(spec.OpenstackEndpointInterface, multinicv1alpha1.DefaultOpenstackEndpointInterface)
 	endpointRegion := resolveString(spec.OpenstackEndpointRegion, "")
 	nodeNameMetadataKey := resolveString(spec.OpenstackNodeNameMetadataKey, "")
-	allowedPortStatuses := resolveAllowedStatuses(spec.OpenstackPortAllowedStatuses, "ACTIVE,DOWN")
-	downPortFastMax := resolveInt(spec.DownPortFastRetryMax, 5)
+	allowedPortStatuses := resolveAllowedStatuses(spec.OpenstackPortAllowedStatuses, strings.Join(multinicv1alpha1.DefaultOpenstackPortAllowedStatuses, ","))
+	downPortFastMax := resolveInt(spec.DownPortFastRetryMax, multinicv1alpha1.DefaultDownPortFastRetryMax)
 	if downPortFastMax < 1 {
 		downPortFastMax = 1
 	}
 
-	pollFast, err := resolveDuration(spec.PollFastInterval, "spec.settings.pollFastInterval", 20*time.Second)
+	pollFast, err := resolveDuration(spec.PollFastInterval, "spec.settings.pollFastInterval", multinicv1alpha1.DefaultPollFastInterval)
 	if err != nil {
 		return out, err
 	}
-	pollSlow, err := resolveDuration(spec.PollSlowInterval, "spec.settings.pollSlowInterval", 2*time.Minute)
+	pollSlow, err := resolveDuration(spec.PollSlowInterval, "spec.settings.pollSlowInterval", multinicv1alpha1.DefaultPollSlowInterval)
 	if err != nil {
 		return out, err
 	}
-	pollError, err := resolveDuration(spec.PollErrorInterval, "spec.settings.pollErrorInterval", 30*time.Second)
+	pollError, err := resolveDuration(spec.PollErrorInterval, "spec.settings.pollErrorInterval", multinicv1alpha1.DefaultPollErrorInterval)
 	if err != nil {
 		return out, err
 	}
-	pollFastWindow, err := resolveDuration(spec.PollFastWindow, "spec.settings.pollFastWindow", 3*time.Minute)
+	pollFastWindow, err := resolveDuration(spec.PollFastWindow, "spec.settings.pollFastWindow", multinicv1alpha1.DefaultPollFastWindow)
 	if err != nil {
 		return out, err
 	}
 	if pollFast <= 0 {
-		pollFast = 20 * time.Second
+		pollFast = multinicv1alpha1.DefaultPollFastInterval
 	}
 	if pollSlow <= 0 {
-		pollSlow = 2 * time.Minute
+		pollSlow = multinicv1alpha1.DefaultPollSlowInterval
 	}
 	if pollSlow < pollFast {
 		pollSlow = pollFast
 	}
 	if pollError <= 0 {
-		pollError = 30 * time.Second
+		pollError = multinicv1alpha1.DefaultPollErrorInterval
 	}
 	if pollFastWindow < 0 {
 		pollFastWindow = 0
 	}
+	contrabassRetry, err := resolveHTTPRetry(spec, spec.ContrabassRetry, "spec.settings.contrabassRetry")
+	if err != nil {
+		return out, err
+	}
+	openstackRetry, err := resolveHTTPRetry(spec, spec.OpenstackRetry, "spec.settings.openstackRetry")
+	if err != nil {
+		return out, err
+	}
+	violaRetry, err := resolveHTTPRetry(spec, spec.ViolaRetry, "spec.settings.violaRetry")
+	if err != nil {
+		return out, err
+	}
+	cbTLS, err := r.resolveTLSConfig(ctx, cfg.Namespace, spec.ContrabassTLS, "spec.settings.contrabassTLS")
+	if err != nil {
+		return out, err
+	}
+	osTLS, err := r.resolveTLSConfig(ctx, cfg.Namespace, spec.OpenstackTLS, "spec.settings.openstackTLS")
+	if err != nil {
+		return out, err
+	}
+	violaTLS, err := r.resolveTLSConfig(ctx, cfg.Namespace, spec.ViolaTLS, "spec.settings.violaTLS")
+	if err != nil {
+		return out, err
+	}
 
 	out = resolvedSettings{
 		contrabassEndpoint:           cbEndpoint,
 		contrabassEncryptKey:         cbEncKey,
 		contrabassTimeout:            cbTimeout,
 		contrabassInsecureTLS:        cbInsecure,
+		contrabassTLS:                cbTLS,
 		violaEndpoint:                violaEndpoint,
 		violaTimeout:                 violaTimeout,
 		violaInsecureTLS:             violaInsecure,
+		violaTLS:                     violaTLS,
 		openstackTimeout:             osTimeout,
 		openstackInsecureTLS:         osInsecure,
+		openstackTLS:                 osTLS,
 		openstackNeutronEndpoint:     neutronOverride,
 		openstackNovaEndpoint:        novaOverride,
 		openstackEndpointInterface:   endpointIface,
@@ -748,10 +1486,69 @@ func (r *OpenstackConfigReconciler) resolveSettings(ctx context.Context, cfg *mu
 		pollSlow:                     pollSlow,
 		pollError:                    pollError,
 		pollFastWindow:               pollFastWindow,
+		contrabassRetry:              contrabassRetry,
+		openstackRetry:               openstackRetry,
+		violaRetry:                   violaRetry,
 	}
 	return out, nil
 }
 
+// resolveHTTPRetry는 API 요청 재시도 정책을 결정한다.
+// override(서비스별 설정, path는 그 field 경로)에 값이 없는 필드는 httpRetry* 공통 값 → 기본값 순으로 채운다.
+func resolveHTTPRetry(spec *multinicv1alpha1.OpenstackConfigSettings, override *multinicv1alpha1.HTTPRetrySettings, path string) (httpretry.Policy, error) {
+	attempts := resolveInt(spec.HTTPRetryMaxAttempts, multinicv1alpha1.DefaultHTTPRetryMaxAttempts)
+	base, err := resolveDuration(spec.HTTPRetryBaseDelay, "spec.settings.httpRetryBaseDelay", multinicv1alpha1.DefaultHTTPRetryBaseDelay)
+	if err != nil {
+		return httpretry.Policy{}, err
+	}
+	maxDelay, err := resolveDuration(spec.HTTPRetryMaxDelay, "spec.settings.httpRetryMaxDelay", multinicv1alpha1.DefaultHTTPRetryMaxDelay)
+	if err != nil {
+		return httpretry.Policy{}, err
+	}
+	if override != nil {
+		attempts = resolveInt(override.MaxAttempts, attempts)
+		if base, err = resolveDuration(override.BaseDelay, path+".baseDelay", base); err != nil {
+			return httpretry.Policy{}, err
+		}
+		if maxDelay, err = resolveDuration(override.MaxDelay, path+".maxDelay", maxDelay); err != nil {
+			return httpretry.Policy{}, err
+		}
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+	return httpretry.Policy{MaxAttempts: attempts, BaseDelay: base, MaxDelay: maxDelay}, nil
+}
+
+// resolveHTTPRetryOrDefault는 삭제 경로용으로, 설정 오류가 있어도 기본 정책으로 계속 진행한다.
+func resolveHTTPRetryOrDefault(log logr.Logger, spec *multinicv1alpha1.OpenstackConfigSettings, override *multinicv1alpha1.HTTPRetrySettings, path string) httpretry.Policy {
+	policy, err := resolveHTTPRetry(spec, override, path)
+	if err != nil {
+		log.Error(err, "invalid retry settings; using defaults")
+		return httpretry.DefaultPolicy()
+	}
+	return policy
+}
+
+// resolveViolaSettings는 CR settings와 오퍼레이터 기본값으로 Viola 접속 정보를 결정한다.
+func (r *OpenstackConfigReconciler) resolveViolaSettings(spec *multinicv1alpha1.OpenstackConfigSettings) (string, time.Duration, bool, error) {
+	endpoint := ""
+	if spec != nil {
+		endpoint = strings.TrimSpace(spec.ViolaEndpoint)
+	}
+	if endpoint == "" {
+		endpoint = strings.TrimSpace(r.ViolaEndpoint)
+	}
+	if endpoint == "" {
+		return "", 0, false, fmt.Errorf("violaEndpoint is required (set spec.settings.violaEndpoint or VIOLA_ENDPOINT)")
+	}
+	timeout := r.ViolaTimeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return endpoint, timeout, r.ViolaInsecureTLS, nil
+}
+
 // parseAllowedStatuses는 허용 포트 상태 목록을 파싱한다. 빈 값/ * 이면 필터링하지 않는다.
 func parseAllowedStatuses(raw string) map[string]struct{} {
 	value := strings.TrimSpace(raw)
@@ -988,6 +1785,141 @@ func (r *OpenstackConfigReconciler) updateDownPortRetryStatus(ctx context.Contex
 	}
 }
 
+// updateResolvedVMsStatus는 결정된 대상 VM ID 목록을 status에 기록한다.
+func (r *OpenstackConfigReconciler) updateResolvedVMsStatus(ctx context.Context, log logr.Logger, cfg *multinicv1alpha1.OpenstackConfig, vmIDs []string) {
+	resolved := append([]string(nil), vmIDs...)
+	sort.Strings(resolved)
+	if len(resolved) == 0 {
+		resolved = nil
+	}
+	if reflect.DeepEqual(cfg.Status.ResolvedVMIDs, resolved) {
+		return
+	}
+	key := types.NamespacedName{Name: cfg.Name, Namespace: cfg.Namespace}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var latest multinicv1alpha1.OpenstackConfig
+		if err := r.Get(ctx, key, &latest); err != nil {
+			return err
+		}
+		if reflect.DeepEqual(latest.Status.ResolvedVMIDs, resolved) {
+			return nil
+		}
+		latest.Status.ResolvedVMIDs = resolved
+		return r.Status().Update(ctx, &latest)
+	})
+	if err != nil && !apierrors.IsConflict(err) {
+		log.Error(err, "resolved vm status update failed")
+	}
+}
+
+// updateNodeStatuses는 VM/노드별 상태 목록을 status에 기록한다.
+func (r *OpenstackConfigReconciler) updateNodeStatuses(ctx context.Context, log logr.Logger, cfg *multinicv1alpha1.OpenstackConfig, statuses []multinicv1alpha1.NodeStatus) {
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+	if reflect.DeepEqual(cfg.Status.Nodes, statuses) {
+		return
+	}
+	key := types.NamespacedName{Name: cfg.Name, Namespace: cfg.Namespace}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var latest multinicv1alpha1.OpenstackConfig
+		if err := r.Get(ctx, key, &latest); err != nil {
+			return err
+		}
+		if reflect.DeepEqual(latest.Status.Nodes, statuses) {
+			return nil
+		}
+		latest.Status.Nodes = statuses
+		return r.Status().Update(ctx, &latest)
+	})
+	if err != nil && !apierrors.IsConflict(err) {
+		log.Error(err, "node status update failed")
+	}
+}
+
+// buildNodeStatuses는 매핑 결과로 VM/노드별 상태를 만든다.
+// 마지막 전송 시각은 같은 nodeName의 이전 상태에서 이어받는다.
+func buildNodeStatuses(nodes []viola.NodeConfig, vmIDToNodeName map[string]string, previous []multinicv1alpha1.NodeStatus) []multinicv1alpha1.NodeStatus {
+	prevByVM := make(map[string]multinicv1alpha1.NodeStatus, len(previous))
+	for _, st := range previous {
+		prevByVM[st.VMID] = st
+	}
+	statuses := make([]multinicv1alpha1.NodeStatus, 0, len(nodes))
+	for _, node := range nodes {
+		normalized := normalizeNodeConfig(node)
+		st := multinicv1alpha1.NodeStatus{
+			VMID:           node.InstanceID,
+			NodeName:       node.NodeName,
+			InterfaceCount: int32(len(node.Interfaces)),
+			Hash:           hashNodeConfig(normalized),
+		}
+		for _, iface := range normalized.Interfaces {
+			if iface.PortID != "" {
+				st.PortIDs = append(st.PortIDs, iface.PortID)
+			}
+		}
+		sort.Strings(st.PortIDs)
+		if prev, ok := prevByVM[node.InstanceID]; ok && prev.NodeName == node.NodeName {
+			st.LastSentAt = prev.LastSentAt
+		}
+		if strings.TrimSpace(vmIDToNodeName[node.InstanceID]) == "" {
+			st.Warning = "node name not resolved; using vm id"
+		}
+		statuses = append(statuses, st)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].VMID < statuses[j].VMID })
+	return statuses
+}
+
+// applyNodeSendResult는 Viola 전송/삭제 결과를 대상 VM의 상태에 반영한다.
+func applyNodeSendResult(statuses []multinicv1alpha1.NodeStatus, vmIDs map[string]struct{}, sentAt *time.Time, sendErr error) {
+	for i := range statuses {
+		if _, ok := vmIDs[statuses[i].VMID]; !ok {
+			continue
+		}
+		if sendErr != nil {
+			statuses[i].Error = sendErr.Error()
+			continue
+		}
+		if sentAt != nil {
+			at := metav1.NewTime(*sentAt)
+			statuses[i].LastSentAt = &at
+		}
+	}
+}
+
+// applyNodeDeleteResult는 Viola 삭제 실패를 삭제 대상 노드의 상태에 기록한다.
+// 대상에서 빠진 VM은 statuses에 없으므로 삭제가 성공할 때까지 오류 항목을 추가해 남긴다.
+func applyNodeDeleteResult(statuses []multinicv1alpha1.NodeStatus, refs []viola.NodeRef, deleteErr error) []multinicv1alpha1.NodeStatus {
+	message := "viola delete failed: " + deleteErr.Error()
+	index := make(map[string]int, len(statuses))
+	for i, st := range statuses {
+		index[st.VMID] = i
+	}
+	for _, ref := range refs {
+		if i, ok := index[ref.InstanceID]; ok {
+			statuses[i].Error = message
+			continue
+		}
+		if ref.InstanceID == "" {
+			// vmID가 없는 이전 레코드는 상태 목록의 키를 만들 수 없으므로 condition 메시지로만 남는다.
+			continue
+		}
+		statuses = append(statuses, multinicv1alpha1.NodeStatus{VMID: ref.InstanceID, NodeName: ref.NodeName, Error: message})
+		index[ref.InstanceID] = len(statuses) - 1
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].VMID < statuses[j].VMID })
+	return statuses
+}
+
+func nodeConfigVMIDs(nodes []viola.NodeConfig) map[string]struct{} {
+	out := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		out[node.InstanceID] = struct{}{}
+	}
+	return out
+}
+
 // selectNodesByName는 지정한 노드 목록만 추린다.
 func selectNodesByName(nodes []viola.NodeConfig, names map[string]struct{}) []viola.NodeConfig {
 	if len(names) == 0 {
@@ -1058,6 +1990,15 @@ func (r *OpenstackConfigReconciler) initCache() {
 	if r.cache == nil {
 		r.cache = make(map[string]cacheEntry)
 	}
+	if r.tokens == nil {
+		r.tokens = openstack.NewTokenCache(openstack.DefaultTokenRefreshBefore)
+	}
+	if r.providers == nil {
+		r.providers = contrabass.NewProviderCache(r.ProviderCacheTTL)
+	}
+	if r.transports == nil {
+		r.transports = httptransport.NewRegistry(poolMetrics{})
+	}
 }
 
 func (r *OpenstackConfigReconciler) initPollState() {
@@ -1066,10 +2007,16 @@ func (r *OpenstackConfigReconciler) initPollState() {
 	if r.lastChange == nil {
 		r.lastChange = make(map[string]time.Time)
 	}
+	if r.reportedSkip == nil {
+		r.reportedSkip = make(map[string]string)
+	}
+	if r.emptyDiscovery == nil {
+		r.emptyDiscovery = make(map[string]bool)
+	}
 }
 
 // filterChanged는 마지막 전송 결과와 비교해 변경된 노드만 추린다.
-func (r *OpenstackConfigReconciler) filterChanged(ctx context.Context, log logr.Logger, providerID string, nodes []viola.NodeConfig) ([]viola.NodeConfig, map[string]string) {
+func (r *OpenstackConfigReconciler) filterChanged(ctx context.Context, log logr.Logger, providerID, owner string, nodes []viola.NodeConfig) ([]viola.NodeConfig, map[string]string) {
 	nodesToSend := make([]viola.NodeConfig, 0, len(nodes))
 	hashes := make(map[string]string)
 	for _, node := range nodes {
@@ -1077,6 +2024,7 @@ func (r *OpenstackConfigReconciler) filterChanged(ctx context.Context, log logr.
 		hash := hashNodeConfig(normalized)
 
 		if entry, ok := r.getCache(providerID, normalized.NodeName); ok && entry.hash == hash {
+			changeCacheLookups.WithLabelValues(cacheResultHit).Inc()
 			if r.Inventory != nil {
 				last, err := r.Inventory.GetHash(ctx, providerID, normalized.NodeName)
 				if err != nil {
@@ -1084,7 +2032,7 @@ func (r *OpenstackConfigReconciler) filterChanged(ctx context.Context, log logr.
 					continue
 				}
 				if last != hash {
-					if err := r.Inventory.Upsert(ctx, providerID, entry.node, entry.hash, time.Now().UTC()); err != nil {
+					if err := r.Inventory.Upsert(ctx, providerID, owner, entry.node, entry.hash, time.Now().UTC()); err != nil {
 						log.Error(err, "inventory upsert failed", "node", normalized.NodeName)
 					}
 				}
@@ -1097,10 +2045,12 @@ func (r *OpenstackConfigReconciler) filterChanged(ctx context.Context, log logr.
 			if err != nil {
 				log.Error(err, "inventory hash lookup failed", "node", normalized.NodeName)
 			} else if last == hash {
-				r.setCache(providerID, normalized.NodeName, cacheEntry{hash: hash, node: normalized})
+				changeCacheLookups.WithLabelValues(cacheResultInventory).Inc()
+				r.setCache(providerID, normalized.NodeName, cacheEntry{hash: hash, owner: owner, node: normalized})
 				continue
 			}
 		}
+		changeCacheLookups.WithLabelValues(cacheResultMiss).Inc()
 
 		nodesToSend = append(nodesToSend, normalized)
 		hashes[normalized.NodeName] = hash
@@ -1121,6 +2071,26 @@ func (r *OpenstackConfigReconciler) setCache(providerID, nodeName string, entry
 	r.cache[providerID+"|"+nodeName] = entry
 }
 
+func (r *OpenstackConfigReconciler) deleteCache(providerID, nodeName string) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	delete(r.cache, providerID+"|"+nodeName)
+}
+
+// listCache는 providerID에 속한 캐시 항목을 nodeName 기준으로 반환한다.
+func (r *OpenstackConfigReconciler) listCache(providerID string) map[string]cacheEntry {
+	r.cacheMu.RLock()
+	defer r.cacheMu.RUnlock()
+	prefix := providerID + "|"
+	out := make(map[string]cacheEntry)
+	for k, entry := range r.cache {
+		if nodeName, ok := strings.CutPrefix(k, prefix); ok {
+			out[nodeName] = entry
+		}
+	}
+	return out
+}
+
 // recordChange는 변경 감지 시점을 저장한다.
 func (r *OpenstackConfigReconciler) recordChange(key string, at time.Time) {
 	r.pollMu.Lock()
@@ -1128,6 +2098,146 @@ func (r *OpenstackConfigReconciler) recordChange(key string, at time.Time) {
 	r.lastChange[key] = at
 }
 
+// emptyDiscoverySources는 지정되었지만 VM을 하나도 찾지 못한 동적 소스(vmSelector/nodeDiscovery)이다.
+// nodeDiscovery는 nodeSelector 오타, 잘못된 kubeconfig context, 일시적으로 빈 클러스터에서도 비어 있을 수 있다.
+func emptyDiscoverySources(spec *multinicv1alpha1.OpenstackConfigSpec, selectorVMs, discoveredVMs int) []string {
+	var sources []string
+	if spec.VMSelector != nil && selectorVMs == 0 {
+		sources = append(sources, "vmSelector")
+	}
+	if spec.NodeDiscovery != nil && discoveredVMs == 0 {
+		sources = append(sources, "nodeDiscovery")
+	}
+	return sources
+}
+
+// confirmDiscoveryEmpty는 vmSelector/nodeDiscovery가 비어 노드를 삭제하려는 폴링이 연속 두 번째인지 확인한다.
+// empty가 false이면 상태를 초기화하고 true를 반환한다.
+func (r *OpenstackConfigReconciler) confirmDiscoveryEmpty(key string, empty bool) bool {
+	r.pollMu.Lock()
+	defer r.pollMu.Unlock()
+	if !empty {
+		delete(r.emptyDiscovery, key)
+		return true
+	}
+	if r.emptyDiscovery[key] {
+		delete(r.emptyDiscovery, key)
+		return true
+	}
+	r.emptyDiscovery[key] = true
+	return false
+}
+
+// forgetPollState는 삭제된 CR의 폴링 상태를 제거한다.
+func (r *OpenstackConfigReconciler) forgetPollState(key string) {
+	r.pollMu.Lock()
+	defer r.pollMu.Unlock()
+	delete(r.lastChange, key)
+	delete(r.emptyDiscovery, key)
+	for k := range r.reportedSkip {
+		if strings.HasPrefix(k, key+"|") {
+			delete(r.reportedSkip, k)
+		}
+	}
+}
+
+// recordEvent는 Recorder가 설정된 경우에만 이벤트를 남긴다.
+func (r *OpenstackConfigReconciler) recordEvent(cfg *multinicv1alpha1.OpenstackConfig, eventType, reason, messageFmt string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(cfg, eventType, reason, messageFmt, args...)
+}
+
+// recordSkippedPorts는 제외된 포트 목록이 바뀐 경우에만 이벤트를 남긴다. (폴링마다 중복 이벤트 방지)
+func (r *OpenstackConfigReconciler) recordSkippedPorts(cfg *multinicv1alpha1.OpenstackConfig, key, reason, detail string, portIDs []string) {
+	stateKey := key + "|" + reason
+	digest := strings.Join(portIDs, ",")
+	r.pollMu.Lock()
+	changed := r.reportedSkip[stateKey] != digest
+	if digest == "" {
+		delete(r.reportedSkip, stateKey)
+	} else {
+		r.reportedSkip[stateKey] = digest
+	}
+	r.pollMu.Unlock()
+	if !changed || digest == "" {
+		return
+	}
+	r.recordEvent(cfg, corev1.EventTypeNormal, reason, "%d port(s) %s: %s", len(portIDs), detail, summarizeNames(portIDs))
+}
+
+// skippedPortIDs는 필터 전 목록에서 빠진 포트 ID를 정렬해 반환한다.
+func skippedPortIDs(before, after []openstack.Port) []string {
+	kept := make(map[string]struct{}, len(after))
+	for _, p := range after {
+		kept[p.ID] = struct{}{}
+	}
+	var skipped []string
+	for _, p := range before {
+		if _, ok := kept[p.ID]; !ok {
+			skipped = append(skipped, p.ID)
+		}
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// summarizeNames는 이벤트 메시지용으로 이름 목록을 maxEventNames개까지 나열한다.
+func summarizeNames(names []string) string {
+	if len(names) <= maxEventNames {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:maxEventNames], ", "), len(names)-maxEventNames)
+}
+
+// summarizeDiffs는 Synced 이벤트에 붙일 노드별 변경 요약이다.
+// 이벤트 크기 제한을 위해 maxEventNames개 노드까지, 노드별로 maxEventDiffLen 문자까지 표시한다.
+func summarizeDiffs(diffs []viola.NodeConfigDiff) string {
+	if len(diffs) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, min(len(diffs), maxEventNames))
+	for i, diff := range diffs {
+		if i == maxEventNames {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s [%s]", diff.NodeName, truncateRunes(diff.String(), maxEventDiffLen)))
+	}
+	out := " (changes: " + strings.Join(parts, ", ")
+	if len(diffs) > maxEventNames {
+		out += fmt.Sprintf(" and %d more", len(diffs)-maxEventNames)
+	}
+	return out + ")"
+}
+
+// truncateRunes는 s가 limit 문자를 넘으면 잘라서 "..."을 붙인다.
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + "..."
+}
+
+func nodeConfigNames(nodes []viola.NodeConfig) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.NodeName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func nodeRefNames(refs []viola.NodeRef) []string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.NodeName)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // getLastChange는 마지막 변경 시점을 조회한다.
 func (r *OpenstackConfigReconciler) getLastChange(key string) (time.Time, bool) {
 	r.pollMu.RLock()
@@ -1138,6 +2248,10 @@ func (r *OpenstackConfigReconciler) getLastChange(key string) (time.Time, bool)
 
 // setReadyCondition은 Ready/Degraded 조건을 한 번에 갱신한다.
 func (r *OpenstackConfigReconciler) setReadyCondition(ctx context.Context, log logr.Logger, cfg *multinicv1alpha1.OpenstackConfig, status metav1.ConditionStatus, reason, message string) {
+	// 실패는 모두 Warning 이벤트로 남겨 kubectl describe에서 확인할 수 있게 한다.
+	if status == metav1.ConditionFalse {
+		r.recordEvent(cfg, corev1.EventTypeWarning, reason, "%s", message)
+	}
 	key := types.NamespacedName{Name: cfg.Name, Namespace: cfg.Namespace}
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		var latest multinicv1alpha1.OpenstackConfig
@@ -1,14 +1,35 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+	"multinic-operator/internal/inventory"
+	"multinic-operator/pkg/apierror"
+	"multinic-operator/pkg/httpretry"
+	"multinic-operator/pkg/notification"
 	"multinic-operator/pkg/openstack"
+	"multinic-operator/pkg/viola"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 func TestMapPortsToNodes_SubnetFilter(t *testing.T) {
@@ -393,3 +414,888 @@ func TestShouldRetryDownPorts(t *testing.T) {
 		t.Fatalf("expected slow retry wait, got should=%v wait=%s", should, wait)
 	}
 }
+
+func TestOwnedNodeRefs(t *testing.T) {
+	store, err := inventory.NewFileStore(filepath.Join(t.TempDir(), "inventory.json"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	ctx := context.Background()
+	now := time.Now()
+	records := []struct {
+		owner string
+		node  viola.NodeConfig
+	}{
+		{owner: "ns/a", node: viola.NodeConfig{NodeName: "node-a", InstanceID: "vm-a"}},
+		{owner: "ns/b", node: viola.NodeConfig{NodeName: "node-b", InstanceID: "vm-b"}},
+		{owner: "", node: viola.NodeConfig{NodeName: "legacy", InstanceID: "vm-legacy"}},
+		{owner: "", node: viola.NodeConfig{NodeName: "orphan", InstanceID: "vm-orphan"}},
+	}
+	for _, rec := range records {
+		if err := store.Upsert(ctx, "provider-1", rec.owner, rec.node, "hash", now); err != nil {
+			t.Fatalf("Upsert error: %v", err)
+		}
+	}
+
+	r := &OpenstackConfigReconciler{Inventory: store}
+	r.initCache()
+	r.setCache("provider-1", "cached", cacheEntry{hash: "h", owner: "ns/a", node: viola.NodeConfig{NodeName: "cached", InstanceID: "vm-c"}})
+	r.setCache("provider-2", "other", cacheEntry{hash: "h", owner: "ns/a", node: viola.NodeConfig{NodeName: "other", InstanceID: "vm-o"}})
+
+	refs, err := r.ownedNodeRefs(ctx, "provider-1", "ns/a", []string{"vm-legacy"})
+	if err != nil {
+		t.Fatalf("ownedNodeRefs error: %v", err)
+	}
+	got := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		got = append(got, ref.NodeName)
+	}
+	want := []string{"cached", "legacy", "node-a"}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	r.purgeNodes(ctx, logr.Discard(), "provider-1", refs)
+	left, err := store.List(ctx, "provider-1", "", "")
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(left) != 2 {
+		t.Fatalf("expected 2 records after purge, got %d", len(left))
+	}
+	if _, ok := r.getCache("provider-1", "cached"); ok {
+		t.Fatalf("expected cache entry to be purged")
+	}
+}
+
+func TestDiffRemovedNodes(t *testing.T) {
+	previous := map[string]viola.NodeConfig{
+		"node-a": {NodeName: "node-a", InstanceID: "vm-a"},
+		"node-b": {NodeName: "node-b", InstanceID: "vm-b"},
+		"node-c": {NodeName: "node-c", InstanceID: "vm-c"},
+	}
+	current := []viola.NodeConfig{
+		{NodeName: "node-b", InstanceID: "vm-b"},
+		{NodeName: "node-d", InstanceID: "vm-d"},
+	}
+
+	removed := diffRemovedNodes(previous, current)
+	if len(removed) != 2 {
+		t.Fatalf("expected 2 removed nodes, got %+v", removed)
+	}
+	if removed[0].NodeName != "node-a" || removed[0].InstanceID != "vm-a" {
+		t.Fatalf("unexpected first removal: %+v", removed[0])
+	}
+	if removed[1].NodeName != "node-c" {
+		t.Fatalf("unexpected second removal: %+v", removed[1])
+	}
+	if got := diffRemovedNodes(nil, current); len(got) != 0 {
+		t.Fatalf("expected no removals without previous state, got %+v", got)
+	}
+}
+
+func TestNodeConfigDiffs(t *testing.T) {
+	previous := map[string]viola.NodeConfig{
+		"node-a": {
+			NodeName: "node-a",
+			Interfaces: []viola.NodeInterface{
+				{PortID: "port-1"},
+				{PortID: "port-2", Name: "multinic1", MTU: 1500},
+				{PortID: "port-3"},
+			},
+		},
+		"node-b": {NodeName: "node-b", Interfaces: []viola.NodeInterface{{PortID: "port-9"}}},
+	}
+	current := []viola.NodeConfig{
+		{NodeName: "node-c", Interfaces: []viola.NodeInterface{{PortID: "port-7"}}},
+		{NodeName: "node-b", Interfaces: []viola.NodeInterface{{PortID: "port-9"}}},
+		{NodeName: "node-a", Interfaces: []viola.NodeInterface{{PortID: "port-2", Name: "multinic1", MTU: 9000}}},
+	}
+
+	// 신규 노드(node-c)와 변경 없는 노드(node-b)는 제외된다.
+	got := nodeConfigDiffs(previous, current)
+	if len(got) != 1 || got[0].NodeName != "node-a" {
+		t.Fatalf("unexpected diffs: %+v", got)
+	}
+	if s := got[0].String(); s != "multinic1 mtu 1500→9000; -port-1; -port-3" {
+		t.Fatalf("unexpected diff summary: %q", s)
+	}
+	if s := summarizeDiffs(got); s != " (changes: node-a [multinic1 mtu 1500→9000; -port-1; -port-3])" {
+		t.Fatalf("unexpected event summary: %q", s)
+	}
+
+	// 인터페이스가 많아도 노드별 요약은 maxEventDiffLen 문자로 자른다.
+	wide := viola.NodeConfig{NodeName: "node-b"}
+	for i := range 32 {
+		wide.Interfaces = append(wide.Interfaces, viola.NodeInterface{PortID: fmt.Sprintf("port-%d", i), Name: fmt.Sprintf("multinic%d", i), MAC: fmt.Sprintf("fa:16:3e:00:00:%02x", i)})
+	}
+	long := summarizeDiffs([]viola.NodeConfigDiff{viola.DiffNodeConfigs(viola.NodeConfig{}, wide)})
+	if n := len([]rune(long)); n > maxEventDiffLen+len(" (changes: node-b [...])") || !strings.HasSuffix(long, "...])") {
+		t.Fatalf("expected truncated diff summary, got %d chars: %q", n, long)
+	}
+}
+
+func TestFillNodeNamesFromPrevious(t *testing.T) {
+	previous := map[string]viola.NodeConfig{
+		"infra01": {NodeName: "infra01", InstanceID: "vm-1"},
+	}
+	mapping := map[string]string{"vm-2": "infra02"}
+
+	fillNodeNamesFromPrevious(mapping, []string{"vm-1", "vm-2", "vm-3"}, previous)
+	if mapping["vm-1"] != "infra01" {
+		t.Fatalf("expected vm-1 to keep last node name, got %q", mapping["vm-1"])
+	}
+	if mapping["vm-2"] != "infra02" {
+		t.Fatalf("expected vm-2 mapping to be kept, got %q", mapping["vm-2"])
+	}
+	if _, ok := mapping["vm-3"]; ok {
+		t.Fatalf("expected vm-3 to stay unresolved")
+	}
+}
+
+func TestCompileVMSelector(t *testing.T) {
+	if _, err := compileVMSelector(&multinicv1alpha1.VMSelector{}); err == nil {
+		t.Fatalf("expected error for empty selector")
+	}
+	if _, err := compileVMSelector(&multinicv1alpha1.VMSelector{NameRegex: "("}); err == nil {
+		t.Fatalf("expected error for invalid nameRegex")
+	}
+	sel, err := compileVMSelector(&multinicv1alpha1.VMSelector{Tags: []string{" multinic ", "multinic", ""}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sel.tags) != 1 || sel.tags[0] != "multinic" {
+		t.Fatalf("expected trimmed unique tags, got %v", sel.tags)
+	}
+}
+
+func TestVMSelectorMatches(t *testing.T) {
+	sel, err := compileVMSelector(&multinicv1alpha1.VMSelector{
+		MatchMetadata: map[string]string{"role": "worker"},
+		NameRegex:     "^infra[0-9]+$",
+		Tags:          []string{"multinic"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	server := openstack.Server{
+		ID:       "vm-1",
+		Name:     "infra01",
+		Metadata: map[string]string{"role": "worker", "zone": "a"},
+		Tags:     []string{"multinic", "gpu"},
+	}
+	if !sel.matches(server) {
+		t.Fatalf("expected server to match selector")
+	}
+
+	noTag := server
+	noTag.Tags = []string{"gpu"}
+	if sel.matches(noTag) {
+		t.Fatalf("expected server without tag to be excluded")
+	}
+	badName := server
+	badName.Name = "db01"
+	if sel.matches(badName) {
+		t.Fatalf("expected server with unmatched name to be excluded")
+	}
+	badMeta := server
+	badMeta.Metadata = map[string]string{"role": "master"}
+	if sel.matches(badMeta) {
+		t.Fatalf("expected server with unmatched metadata to be excluded")
+	}
+}
+
+func TestNodeNameFromServer(t *testing.T) {
+	server := openstack.Server{ID: "vm-1", Name: "infra01", Metadata: map[string]string{"k8s-node": "worker-1"}}
+	if got := nodeNameFromServer(server, "k8s-node"); got != "worker-1" {
+		t.Fatalf("expected metadata node name, got %q", got)
+	}
+	if got := nodeNameFromServer(server, ""); got != "infra01" {
+		t.Fatalf("expected server name, got %q", got)
+	}
+	if got := nodeNameFromServer(openstack.Server{ID: "vm-2"}, ""); got != "vm-2" {
+		t.Fatalf("expected vm id fallback, got %q", got)
+	}
+}
+
+func TestResolveNodeNames_BulkFallbackToGetServer(t *testing.T) {
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/servers/detail" {
+			// 일괄 조회는 vm-0만 반환한다. 나머지는 개별 조회로 채워야 한다.
+			_ = json.NewEncoder(w).Encode(map[string]any{"servers": []openstack.Server{{ID: "vm-0", Name: "node-0"}}})
+			return
+		}
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			cur := maxInFlight.Load()
+			if n <= cur || maxInFlight.CompareAndSwap(cur, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		id := strings.TrimPrefix(r.URL.Path, "/servers/")
+		switch id {
+		case "vm-missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "vm-forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"server": openstack.Server{ID: id, Name: "node-" + strings.TrimPrefix(id, "vm-")}})
+	}))
+	defer srv.Close()
+
+	vmIDs := []string{"vm-missing", "vm-forbidden"}
+	for i := 0; i < 20; i++ {
+		vmIDs = append(vmIDs, fmt.Sprintf("vm-%d", i))
+	}
+	nova := openstack.NewNovaClient(srv.URL, 5*time.Second)
+	auth := func(fn func(token string) error) error { return fn("token") }
+	got, err := resolveNodeNames(context.Background(), logr.Discard(), nova, auth, vmIDs, "", 5*time.Second)
+	if len(got) != 20 || got["vm-0"] != "node-0" || got["vm-19"] != "node-19" {
+		t.Fatalf("unexpected node names: %v", got)
+	}
+	if _, ok := got["vm-missing"]; ok {
+		t.Fatalf("expected failed lookup to be omitted")
+	}
+	// 삭제된 VM(404)은 오류가 아니고, 그 외 실패만 stage 오류로 집계한다.
+	if err == nil || !strings.Contains(err.Error(), "vm-forbidden") || strings.Contains(err.Error(), "vm-missing") {
+		t.Fatalf("expected aggregated error for vm-forbidden only, got %v", err)
+	}
+	if maxInFlight.Load() > novaLookupConcurrency {
+		t.Fatalf("expected at most %d concurrent lookups, got %d", novaLookupConcurrency, maxInFlight.Load())
+	}
+}
+
+func TestResolveNodeNames_Timeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	vmIDs := make([]string, 0, 40)
+	for i := 0; i < 40; i++ {
+		vmIDs = append(vmIDs, fmt.Sprintf("vm-%d", i))
+	}
+	nova := openstack.NewNovaClient(srv.URL, time.Minute)
+	auth := func(fn func(token string) error) error { return fn("token") }
+	start := time.Now()
+	got, err := resolveNodeNames(context.Background(), logr.Discard(), nova, auth, vmIDs, "", 100*time.Millisecond)
+	if len(got) != 0 || err == nil {
+		t.Fatalf("expected no node names and a timeout error, got %v (%v)", got, err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected lookup to stop at timeout, took %s", elapsed)
+	}
+}
+
+func TestParseOpenstackProviderID(t *testing.T) {
+	cases := map[string]string{
+		"openstack:///08186d75-754e-449c-b210-c0ea822727a7":          "08186d75-754e-449c-b210-c0ea822727a7",
+		"openstack://RegionOne/c863944f-5cfe-4e05-805f-7522f3e9b080": "c863944f-5cfe-4e05-805f-7522f3e9b080",
+	}
+	for providerID, want := range cases {
+		got, ok := parseOpenstackProviderID(providerID)
+		if !ok || got != want {
+			t.Fatalf("providerID %q: expected %q, got %q (ok=%v)", providerID, want, got, ok)
+		}
+	}
+	for _, providerID := range []string{"", "aws:///i-123", "openstack:///"} {
+		if got, ok := parseOpenstackProviderID(providerID); ok {
+			t.Fatalf("providerID %q: expected no match, got %q", providerID, got)
+		}
+	}
+}
+
+func TestVMIDsFromNodes(t *testing.T) {
+	node := func(name, providerID string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: corev1.NodeSpec{ProviderID: providerID}}
+	}
+	nodes := []corev1.Node{
+		node("worker-2", "openstack:///vm-2"),
+		node("worker-1", "openstack:///vm-1"),
+		node("kind-node", ""),
+		node("worker-dup", "openstack:///vm-1"),
+	}
+
+	vmIDs, names := vmIDsFromNodes(logr.Discard(), nodes)
+	if len(vmIDs) != 2 || vmIDs[0] != "vm-1" || vmIDs[1] != "vm-2" {
+		t.Fatalf("expected sorted vm ids [vm-1 vm-2], got %v", vmIDs)
+	}
+	if names["vm-1"] != "worker-1" || names["vm-2"] != "worker-2" {
+		t.Fatalf("unexpected node name mapping: %v", names)
+	}
+}
+
+func TestBuildNodeStatuses(t *testing.T) {
+	sentAt := metav1.NewTime(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	previous := []multinicv1alpha1.NodeStatus{
+		{VMID: "vm-1", NodeName: "infra01", LastSentAt: &sentAt},
+		{VMID: "vm-2", NodeName: "old-name", LastSentAt: &sentAt},
+	}
+	nodes := []viola.NodeConfig{
+		{NodeName: "vm-2", InstanceID: "vm-2"},
+		{NodeName: "infra01", InstanceID: "vm-1", Interfaces: []viola.NodeInterface{
+			{PortID: "port-b", MAC: "fa:16:3e:00:00:02"},
+			{PortID: "port-a", MAC: "fa:16:3e:00:00:01"},
+		}},
+	}
+
+	statuses := buildNodeStatuses(nodes, map[string]string{"vm-1": "infra01"}, previous)
+	if len(statuses) != 2 || statuses[0].VMID != "vm-1" || statuses[1].VMID != "vm-2" {
+		t.Fatalf("expected statuses sorted by vm id, got %+v", statuses)
+	}
+	first := statuses[0]
+	if first.InterfaceCount != 2 || len(first.PortIDs) != 2 || first.PortIDs[0] != "port-a" {
+		t.Fatalf("unexpected interface status: %+v", first)
+	}
+	if first.Hash == "" || first.LastSentAt == nil || !first.LastSentAt.Equal(&sentAt) {
+		t.Fatalf("expected hash and carried lastSentAt, got %+v", first)
+	}
+	if first.Error != "" {
+		t.Fatalf("expected no error for resolved node, got %q", first.Error)
+	}
+	second := statuses[1]
+	if second.LastSentAt != nil {
+		t.Fatalf("expected lastSentAt reset after node name change, got %v", second.LastSentAt)
+	}
+	if second.Error != "" || second.Warning == "" {
+		t.Fatalf("expected unresolved node name warning without error, got %+v", second)
+	}
+}
+
+func TestApplyNodeDeleteResult(t *testing.T) {
+	statuses := []multinicv1alpha1.NodeStatus{{VMID: "vm-2", NodeName: "worker-2"}, {VMID: "vm-3", NodeName: "worker-3"}}
+	refs := []viola.NodeRef{
+		{NodeName: "worker-1", InstanceID: "vm-1"},
+		{NodeName: "worker-3", InstanceID: "vm-3"},
+		{NodeName: "legacy"},
+	}
+
+	// 대상에서 빠진 VM도 삭제 실패 항목으로 남고, vmID가 없는 레코드는 건너뛴다.
+	statuses = applyNodeDeleteResult(statuses, refs, fmt.Errorf("viola down"))
+	if len(statuses) != 3 || statuses[0].VMID != "vm-1" || statuses[0].NodeName != "worker-1" {
+		t.Fatalf("expected removed vm status to be added in order, got %+v", statuses)
+	}
+	if statuses[0].Error == "" || statuses[1].Error != "" || statuses[2].Error == "" {
+		t.Fatalf("expected delete error on removed nodes only, got %+v", statuses)
+	}
+}
+
+func TestApplyNodeSendResult(t *testing.T) {
+	statuses := []multinicv1alpha1.NodeStatus{{VMID: "vm-1"}, {VMID: "vm-2"}}
+	now := time.Now()
+
+	applyNodeSendResult(statuses, map[string]struct{}{"vm-1": {}}, &now, nil)
+	if statuses[0].LastSentAt == nil || statuses[1].LastSentAt != nil {
+		t.Fatalf("expected only vm-1 to be marked sent: %+v", statuses)
+	}
+
+	applyNodeSendResult(statuses, map[string]struct{}{"vm-2": {}}, nil, fmt.Errorf("viola down"))
+	if statuses[1].Error != "viola down" || statuses[0].Error != "" {
+		t.Fatalf("expected only vm-2 to carry error: %+v", statuses)
+	}
+}
+
+func TestSkippedPortIDs(t *testing.T) {
+	before := []openstack.Port{{ID: "port-c"}, {ID: "port-a"}, {ID: "port-b"}}
+	after := []openstack.Port{{ID: "port-b"}}
+
+	skipped := skippedPortIDs(before, after)
+	if len(skipped) != 2 || skipped[0] != "port-a" || skipped[1] != "port-c" {
+		t.Fatalf("expected [port-a port-c], got %v", skipped)
+	}
+	if skipped := skippedPortIDs(after, after); len(skipped) != 0 {
+		t.Fatalf("expected no skipped ports, got %v", skipped)
+	}
+}
+
+func TestSummarizeNames(t *testing.T) {
+	if got := summarizeNames([]string{"a", "b"}); got != "a, b" {
+		t.Fatalf("unexpected summary: %q", got)
+	}
+	names := make([]string, maxEventNames+3)
+	for i := range names {
+		names[i] = fmt.Sprintf("node%02d", i)
+	}
+	got := summarizeNames(names)
+	if want := "and 3 more"; got[len(got)-len(want):] != want {
+		t.Fatalf("expected truncated summary, got %q", got)
+	}
+}
+
+func TestRecordSkippedPorts_Dedup(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &OpenstackConfigReconciler{Recorder: recorder}
+	r.initPollState()
+	cfg := &multinicv1alpha1.OpenstackConfig{ObjectMeta: metav1.ObjectMeta{Name: "cfg", Namespace: "default"}}
+
+	r.recordSkippedPorts(cfg, "default/cfg", "PortsSkippedByStatus", "not in allowed statuses", []string{"port-a"})
+	r.recordSkippedPorts(cfg, "default/cfg", "PortsSkippedByStatus", "not in allowed statuses", []string{"port-a"})
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected 1 event for unchanged skip list, got %d", len(recorder.Events))
+	}
+	<-recorder.Events
+
+	r.recordSkippedPorts(cfg, "default/cfg", "PortsSkippedByStatus", "not in allowed statuses", nil)
+	r.recordSkippedPorts(cfg, "default/cfg", "PortsSkippedByStatus", "not in allowed statuses", []string{"port-a"})
+	if len(recorder.Events) != 1 {
+		t.Fatalf("expected event again after skip list changed, got %d", len(recorder.Events))
+	}
+	if event := <-recorder.Events; event != "Normal PortsSkippedByStatus 1 port(s) not in allowed statuses: port-a" {
+		t.Fatalf("unexpected event: %q", event)
+	}
+}
+
+func TestNotificationMatches(t *testing.T) {
+	cfg := &multinicv1alpha1.OpenstackConfig{
+		Spec: multinicv1alpha1.OpenstackConfigSpec{
+			VmNames: []string{"vm-1"},
+			Credentials: multinicv1alpha1.OpenstackCredentials{
+				OpenstackProviderID: "provider-1",
+				ProjectID:           "project-1",
+			},
+		},
+		Status: multinicv1alpha1.OpenstackConfigStatus{
+			ResolvedVMIDs: []string{"vm-1", "vm-2"},
+			Nodes:         []multinicv1alpha1.NodeStatus{{VMID: "vm-2", PortIDs: []string{"port-2"}}},
+		},
+	}
+	cases := []struct {
+		name       string
+		providerID string
+		ev         notification.Event
+		want       bool
+	}{
+		{"vmNames", "provider-1", notification.Event{VMID: "vm-1", ProjectID: "project-1"}, true},
+		{"resolved vm", "provider-1", notification.Event{VMID: "vm-2"}, true},
+		{"known port delete", "provider-1", notification.Event{PortID: "port-2"}, true},
+		{"other vm", "provider-1", notification.Event{VMID: "vm-3", ProjectID: "project-1"}, false},
+		{"other project", "provider-1", notification.Event{VMID: "vm-1", ProjectID: "project-2"}, false},
+		{"other provider", "provider-2", notification.Event{VMID: "vm-1"}, false},
+	}
+	for _, tc := range cases {
+		if got := notificationMatches(cfg, tc.providerID, tc.ev); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+
+	// vmSelector는 새 VM도 대상이 될 수 있으므로 같은 프로젝트의 VM 이벤트를 받는다.
+	cfg.Spec.VMSelector = &multinicv1alpha1.VMSelector{NameRegex: "^worker-"}
+	if !notificationMatches(cfg, "provider-1", notification.Event{VMID: "vm-3", ProjectID: "project-1"}) {
+		t.Fatalf("expected selector config to match new vm in project")
+	}
+}
+
+func TestResolveSettings_OperatorConfigLayering(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	if err := multinicv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	opCfg := &multinicv1alpha1.OpenstackOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: multinicv1alpha1.DefaultOperatorConfigName},
+		Spec: multinicv1alpha1.OpenstackOperatorConfigSpec{Settings: &multinicv1alpha1.OpenstackConfigSettings{
+			ContrabassEndpoint:   "https://contrabass.example.com",
+			ContrabassEncryptKey: "operator-key",
+			ViolaEndpoint:        "http://viola.example.com",
+			PollFastInterval:     "10s",
+			PollSlowInterval:     "5m",
+		}},
+	}
+	cfgA := &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"},
+		Spec: multinicv1alpha1.OpenstackConfigSpec{
+			Settings: &multinicv1alpha1.OpenstackConfigSettings{PollSlowInterval: "1m"},
+		},
+	}
+	cfgB := &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns"},
+		Spec:       multinicv1alpha1.OpenstackConfigSpec{OperatorConfigName: "missing"},
+	}
+	r := &OpenstackConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(opCfg, cfgA, cfgB).Build(),
+	}
+	ctx := context.Background()
+
+	// CR 값이 우선하고, 비어 있는 필드는 OpenstackOperatorConfig → 내장 기본값 순으로 채운다.
+	settings, err := r.resolveSettings(ctx, cfgA)
+	if err != nil {
+		t.Fatalf("resolveSettings error: %v", err)
+	}
+	if settings.contrabassEndpoint != "https://contrabass.example.com" || settings.contrabassEncryptKey != "operator-key" {
+		t.Fatalf("expected operator defaults, got %+v", settings)
+	}
+	if settings.pollFast != 10*time.Second || settings.pollSlow != time.Minute {
+		t.Fatalf("unexpected poll intervals: fast=%s slow=%s", settings.pollFast, settings.pollSlow)
+	}
+	if settings.pollError != multinicv1alpha1.DefaultPollErrorInterval {
+		t.Fatalf("expected built-in pollError default, got %s", settings.pollError)
+	}
+
+	// 명시한 OpenstackOperatorConfig가 없으면 설정 오류이다.
+	if _, err := r.resolveSettings(ctx, cfgB); err == nil || !strings.Contains(err.Error(), "missing") {
+		t.Fatalf("expected missing operator config error, got %v", err)
+	}
+
+	reqs := r.mapOperatorConfig(ctx, opCfg)
+	if len(reqs) != 1 || reqs[0].Name != "a" {
+		t.Fatalf("expected only default-referencing config to be enqueued, got %v", reqs)
+	}
+}
+
+func TestMapSecret_ReferencingConfigs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	if err := multinicv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	withRef := &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "with-ref", Namespace: "ns"},
+		Spec: multinicv1alpha1.OpenstackConfigSpec{
+			Secrets: &multinicv1alpha1.OpenstackConfigSecrets{
+				ContrabassEncryptKeySecretRef: &multinicv1alpha1.SecretKeyRef{Name: "enc", Key: "KEY"},
+			},
+			NodeDiscovery: &multinicv1alpha1.NodeDiscovery{
+				KubeconfigSecretRef: multinicv1alpha1.SecretKeyRef{Name: "kubeconfig", Key: "value"},
+			},
+		},
+	}
+	defaultKey := &multinicv1alpha1.OpenstackConfig{ObjectMeta: metav1.ObjectMeta{Name: "default-key", Namespace: "ns"}}
+	otherNS := &multinicv1alpha1.OpenstackConfig{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other-ns"}}
+	r := &OpenstackConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(withRef, defaultKey, otherNS).
+			WithIndex(&multinicv1alpha1.OpenstackConfig{}, secretRefIndex, indexSecretRefs).
+			Build(),
+	}
+	ctx := context.Background()
+	secret := func(name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"}}
+	}
+
+	for name, want := range map[string]string{
+		"enc":        "with-ref",
+		"kubeconfig": "with-ref",
+		multinicv1alpha1.DefaultContrabassEncryptKeySecretName: "default-key",
+	} {
+		reqs := r.mapSecret(ctx, secret(name))
+		if len(reqs) != 1 || reqs[0].Name != want || reqs[0].Namespace != "ns" {
+			t.Fatalf("secret %s: expected %s, got %v", name, want, reqs)
+		}
+	}
+	if reqs := r.mapSecret(ctx, secret("unrelated")); len(reqs) != 0 {
+		t.Fatalf("expected no requests for unrelated secret, got %v", reqs)
+	}
+}
+
+func TestMapInheritedRefs_OperatorConfigTLS(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	if err := multinicv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	opCfg := &multinicv1alpha1.OpenstackOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: multinicv1alpha1.DefaultOperatorConfigName},
+		Spec: multinicv1alpha1.OpenstackOperatorConfigSpec{Settings: &multinicv1alpha1.OpenstackConfigSettings{
+			ContrabassTLS: &multinicv1alpha1.TLSSettings{ClientCertSecretName: "op-cert"},
+			ViolaTLS:      &multinicv1alpha1.TLSSettings{CABundleRef: &multinicv1alpha1.CABundleRef{Name: "op-ca"}},
+		}},
+	}
+	inherits := &multinicv1alpha1.OpenstackConfig{ObjectMeta: metav1.ObjectMeta{Name: "inherits", Namespace: "ns"}}
+	overrides := &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "overrides", Namespace: "ns"},
+		Spec: multinicv1alpha1.OpenstackConfigSpec{Settings: &multinicv1alpha1.OpenstackConfigSettings{
+			ViolaTLS: &multinicv1alpha1.TLSSettings{CABundleRef: &multinicv1alpha1.CABundleRef{Name: "own-ca"}},
+		}},
+	}
+	otherOp := &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-op", Namespace: "ns"},
+		Spec:       multinicv1alpha1.OpenstackConfigSpec{OperatorConfigName: "other"},
+	}
+	otherNS := &multinicv1alpha1.OpenstackConfig{ObjectMeta: metav1.ObjectMeta{Name: "other-ns", Namespace: "other-ns"}}
+	r := &OpenstackConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(opCfg, inherits, overrides, otherOp, otherNS).
+			WithIndex(&multinicv1alpha1.OpenstackConfig{}, secretRefIndex, indexSecretRefs).
+			WithIndex(&multinicv1alpha1.OpenstackConfig{}, configMapRefIndex, indexConfigMapRefs).
+			Build(),
+	}
+	ctx := context.Background()
+	names := func(reqs []ctrl.Request) string {
+		out := make([]string, 0, len(reqs))
+		for _, req := range reqs {
+			out = append(out, req.Name)
+		}
+		sort.Strings(out)
+		return fmt.Sprint(out)
+	}
+
+	// OpenstackOperatorConfig에서 상속한 참조도 재조정 대상이고, CR에서 덮어쓴 경우는 제외한다.
+	if got := names(r.mapConfigMap(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "op-ca", Namespace: "ns"}})); got != "[inherits]" {
+		t.Fatalf("unexpected configmap requests: %s", got)
+	}
+	if got := names(r.mapConfigMap(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "own-ca", Namespace: "ns"}})); got != "[overrides]" {
+		t.Fatalf("unexpected own configmap requests: %s", got)
+	}
+	if got := names(r.mapSecret(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "op-cert", Namespace: "ns"}})); got != "[inherits overrides]" {
+		t.Fatalf("unexpected secret requests: %s", got)
+	}
+}
+
+func TestSecretDataChanged(t *testing.T) {
+	p := secretDataChanged()
+	oldSecret := &corev1.Secret{Data: map[string][]byte{"KEY": []byte("a")}}
+	relabeled := oldSecret.DeepCopy()
+	relabeled.Labels = map[string]string{"team": "net"}
+	if p.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: relabeled}) {
+		t.Fatalf("expected metadata-only update to be filtered")
+	}
+	rotated := oldSecret.DeepCopy()
+	rotated.Data["KEY"] = []byte("b")
+	if !p.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: rotated}) {
+		t.Fatalf("expected data change to pass")
+	}
+}
+
+func TestAPIErrorReasonAndRequeue(t *testing.T) {
+	apiErr := func(code int) error {
+		return &apierror.Error{Service: "neutron", Operation: "list ports", StatusCode: code}
+	}
+	pollError, pollSlow := 30*time.Second, 2*time.Minute
+	cases := []struct {
+		err         error
+		wantReason  string
+		wantRequeue time.Duration
+	}{
+		{err: fmt.Errorf("dial tcp: timeout"), wantReason: "NeutronPortError", wantRequeue: pollError},
+		{err: apiErr(http.StatusUnauthorized), wantReason: "NeutronPortUnauthorized", wantRequeue: pollSlow},
+		{err: apiErr(http.StatusNotFound), wantReason: "NeutronPortNotFound", wantRequeue: pollSlow},
+		{err: apiErr(http.StatusConflict), wantReason: "NeutronPortConflict", wantRequeue: conflictRequeue},
+		{err: apiErr(http.StatusServiceUnavailable), wantReason: "NeutronPortUnavailable", wantRequeue: pollError},
+		{err: apiErr(http.StatusBadRequest), wantReason: "NeutronPortRejected", wantRequeue: pollSlow},
+		{
+			err:         &apierror.Error{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Minute},
+			wantReason:  "NeutronPortRateLimited",
+			wantRequeue: 5 * time.Minute,
+		},
+	}
+	for _, tc := range cases {
+		if got := apiErrorReason("NeutronPortError", tc.err); got != tc.wantReason {
+			t.Fatalf("%v: expected reason %s, got %s", tc.err, tc.wantReason, got)
+		}
+		if got := apiErrorRequeue(tc.err, pollError, pollSlow); got != tc.wantRequeue {
+			t.Fatalf("%v: expected requeue %s, got %s", tc.err, tc.wantRequeue, got)
+		}
+	}
+}
+
+func TestResolveTLSConfig_CABundleConfigMap(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	if err := multinicv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	cfg := &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cfg", Namespace: "ns"},
+		Spec: multinicv1alpha1.OpenstackConfigSpec{
+			Settings: &multinicv1alpha1.OpenstackConfigSettings{
+				ViolaTLS: &multinicv1alpha1.TLSSettings{CABundleRef: &multinicv1alpha1.CABundleRef{Name: "private-ca"}},
+			},
+		},
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "private-ca", Namespace: "ns"},
+		Data:       map[string]string{multinicv1alpha1.DefaultCABundleKey: string(caPEM)},
+	}
+	r := &OpenstackConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(cfg, cm).
+			WithIndex(&multinicv1alpha1.OpenstackConfig{}, configMapRefIndex, indexConfigMapRefs).
+			Build(),
+	}
+	ctx := context.Background()
+
+	material, err := r.resolveTLSConfig(ctx, "ns", cfg.Spec.Settings.ViolaTLS, "spec.settings.violaTLS")
+	if err != nil {
+		t.Fatalf("resolve tls config: %v", err)
+	}
+	if material.fingerprint == "" {
+		t.Fatalf("expected fingerprint for CA bundle")
+	}
+	refs := []viola.NodeRef{{NodeName: "worker-1"}}
+	noRetry := viola.WithRetry(httpretry.Policy{MaxAttempts: 1})
+	if err := viola.NewClient(srv.URL, 5*time.Second, viola.WithTLSConfig(material.config), noRetry).DeleteNodeConfigs(ctx, refs); err != nil {
+		t.Fatalf("expected private CA to be trusted, got %v", err)
+	}
+	if err := viola.NewClient(srv.URL, 5*time.Second, noRetry).DeleteNodeConfigs(ctx, refs); err == nil {
+		t.Fatalf("expected verification failure without CA bundle")
+	}
+
+	if _, err := r.resolveTLSConfig(ctx, "ns", &multinicv1alpha1.TLSSettings{CABundleRef: &multinicv1alpha1.CABundleRef{Name: "missing"}}, "spec.settings.violaTLS"); err == nil {
+		t.Fatalf("expected error for missing configmap")
+	}
+	if reqs := r.mapConfigMap(ctx, cm); len(reqs) != 1 || reqs[0].Name != "cfg" {
+		t.Fatalf("expected configmap change to enqueue cfg, got %v", reqs)
+	}
+}
+
+func TestSharedTransport_ReusedAcrossReconciles(t *testing.T) {
+	r := &OpenstackConfigReconciler{}
+	r.initCache()
+	first := r.sharedTransport(transportViola, tlsMaterial{}, false, 30*time.Second)
+	if again := r.sharedTransport(transportViola, tlsMaterial{}, false, 30*time.Second); again != first {
+		t.Fatalf("expected same transport for identical settings")
+	}
+	if other := r.sharedTransport(transportViola, tlsMaterial{fingerprint: "abc"}, false, 30*time.Second); other == first {
+		t.Fatalf("expected distinct transport for different TLS material")
+	}
+	if other := r.sharedTransport(transportOpenstack, tlsMaterial{}, false, 30*time.Second); other == first {
+		t.Fatalf("expected distinct transport per service")
+	}
+	if got := r.transports.Len(); got != 3 {
+		t.Fatalf("expected 3 pooled transports, got %d", got)
+	}
+}
+
+func TestResolveHTTPRetry_ServiceOverride(t *testing.T) {
+	attempts, once := int32(5), int32(1)
+	spec := &multinicv1alpha1.OpenstackConfigSettings{
+		HTTPRetryMaxAttempts: &attempts,
+		HTTPRetryBaseDelay:   "1s",
+		ViolaRetry:           &multinicv1alpha1.HTTPRetrySettings{MaxAttempts: &once},
+		OpenstackRetry:       &multinicv1alpha1.HTTPRetrySettings{MaxDelay: "1m"},
+	}
+
+	// 서비스별 값이 없는 필드는 httpRetry* 공통 값 → 기본값 순으로 채운다.
+	for name, tc := range map[string]struct {
+		override *multinicv1alpha1.HTTPRetrySettings
+		want     httpretry.Policy
+	}{
+		"contrabass": {nil, httpretry.Policy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: multinicv1alpha1.DefaultHTTPRetryMaxDelay}},
+		"openstack":  {spec.OpenstackRetry, httpretry.Policy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Minute}},
+		"viola":      {spec.ViolaRetry, httpretry.Policy{MaxAttempts: 1, BaseDelay: time.Second, MaxDelay: multinicv1alpha1.DefaultHTTPRetryMaxDelay}},
+	} {
+		got, err := resolveHTTPRetry(spec, tc.override, "spec.settings."+name+"Retry")
+		if err != nil || got != tc.want {
+			t.Fatalf("%s: expected %+v, got %+v (%v)", name, tc.want, got, err)
+		}
+	}
+
+	bad := &multinicv1alpha1.HTTPRetrySettings{BaseDelay: "soon"}
+	if _, err := resolveHTTPRetry(spec, bad, "spec.settings.violaRetry"); err == nil || !strings.Contains(err.Error(), "spec.settings.violaRetry.baseDelay") {
+		t.Fatalf("expected override field error, got %v", err)
+	}
+}
+
+func TestConfirmDiscoveryEmpty(t *testing.T) {
+	r := &OpenstackConfigReconciler{}
+	r.initPollState()
+
+	// 첫 번째 빈 결과는 삭제를 보류하고, 연속 두 번째에서만 삭제를 허용한다.
+	if r.confirmDiscoveryEmpty("default/cfg", true) {
+		t.Fatalf("expected first empty selector poll to defer removal")
+	}
+	if !r.confirmDiscoveryEmpty("default/cfg", true) {
+		t.Fatalf("expected second consecutive empty poll to allow removal")
+	}
+
+	// 중간에 VM이 다시 잡히면 다시 두 번 연속이 필요하다.
+	r.confirmDiscoveryEmpty("default/cfg", true)
+	if !r.confirmDiscoveryEmpty("default/cfg", false) {
+		t.Fatalf("expected non-empty poll to pass")
+	}
+	if r.confirmDiscoveryEmpty("default/cfg", true) {
+		t.Fatalf("expected empty streak to restart after a non-empty poll")
+	}
+}
+
+func TestEmptyDiscoverySources(t *testing.T) {
+	spec := &multinicv1alpha1.OpenstackConfigSpec{
+		VmNames:       []string{"vm-1"},
+		NodeDiscovery: &multinicv1alpha1.NodeDiscovery{},
+	}
+	// Node가 하나도 없으면 vmNames가 있어도 nodeDiscovery 노드 삭제는 확인 후에만 한다.
+	if got := emptyDiscoverySources(spec, 0, 0); fmt.Sprint(got) != "[nodeDiscovery]" {
+		t.Fatalf("expected empty nodeDiscovery, got %v", got)
+	}
+	if got := emptyDiscoverySources(spec, 0, 2); len(got) != 0 {
+		t.Fatalf("expected no empty source, got %v", got)
+	}
+	spec.VMSelector = &multinicv1alpha1.VMSelector{}
+	if got := emptyDiscoverySources(spec, 0, 0); fmt.Sprint(got) != "[vmSelector nodeDiscovery]" {
+		t.Fatalf("expected both sources empty, got %v", got)
+	}
+	if got := emptyDiscoverySources(&multinicv1alpha1.OpenstackConfigSpec{VmNames: []string{"vm-1"}}, 0, 0); len(got) != 0 {
+		t.Fatalf("expected static vmNames to never hold removal, got %v", got)
+	}
+}
+
+func TestReconcileDelete_KeepsInventoryWhenTeardownSkipped(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	if err := multinicv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	store, err := inventory.NewFileStore(filepath.Join(t.TempDir(), "inventory.json"))
+	if err != nil {
+		t.Fatalf("NewStore error: %v", err)
+	}
+	ctx := context.Background()
+	if err := store.Upsert(ctx, "provider-1", "ns/a", viola.NodeConfig{NodeName: "node-a", InstanceID: "vm-a"}, "hash", time.Now()); err != nil {
+		t.Fatalf("Upsert error: %v", err)
+	}
+	now := metav1.Now()
+	cfg := &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns", Finalizers: []string{openstackConfigFinalizer}, DeletionTimestamp: &now},
+		Spec:       multinicv1alpha1.OpenstackConfigSpec{Credentials: multinicv1alpha1.OpenstackCredentials{K8sProviderID: "provider-1"}},
+	}
+	recorder := record.NewFakeRecorder(10)
+	r := &OpenstackConfigReconciler{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(cfg).Build(),
+		Recorder:  recorder,
+		Inventory: store,
+	}
+	r.initCache()
+	r.initPollState()
+
+	// Viola 주소가 없어 정리를 건너뛰면 finalizer는 풀되 Inventory 레코드는 남긴다.
+	if _, err := r.reconcileDelete(ctx, logr.Discard(), cfg); err != nil {
+		t.Fatalf("reconcileDelete error: %v", err)
+	}
+	left, err := store.List(ctx, "provider-1", "", "")
+	if err != nil {
+		t.Fatalf("List error: %v", err)
+	}
+	if len(left) != 1 || left[0].NodeName != "node-a" {
+		t.Fatalf("expected inventory record to be kept, got %+v", left)
+	}
+	select {
+	case ev := <-recorder.Events:
+		if !strings.HasPrefix(ev, "Warning NodeConfigTeardownSkipped") {
+			t.Fatalf("unexpected event: %s", ev)
+		}
+	default:
+		t.Fatalf("expected teardown skipped warning event")
+	}
+}
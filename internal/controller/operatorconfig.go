@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+)
+
+// operatorConfigName은 CR이 참조하는 OpenstackOperatorConfig 이름과 명시 여부를 반환한다.
+func operatorConfigName(cfg *multinicv1alpha1.OpenstackConfig) (string, bool) {
+	if name := strings.TrimSpace(cfg.Spec.OperatorConfigName); name != "" {
+		return name, true
+	}
+	return multinicv1alpha1.DefaultOperatorConfigName, false
+}
+
+// operatorSettings는 CR이 참조하는 OpenstackOperatorConfig의 settings를 조회한다.
+// 기본 이름("default")은 없어도 되지만, 명시한 이름이 없으면 오류로 처리한다.
+func (r *OpenstackConfigReconciler) operatorSettings(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig) (*multinicv1alpha1.OpenstackConfigSettings, error) {
+	name, explicit := operatorConfigName(cfg)
+	var opCfg multinicv1alpha1.OpenstackOperatorConfig
+	if err := r.Get(ctx, types.NamespacedName{Name: name}, &opCfg); err != nil {
+		if apierrors.IsNotFound(err) && !explicit {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("openstackoperatorconfig %s: %w", name, err)
+	}
+	return opCfg.Spec.Settings, nil
+}
+
+// effectiveSettings는 OpenstackOperatorConfig settings 위에 CR settings를 겹친 결과를 반환한다.
+func (r *OpenstackConfigReconciler) effectiveSettings(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig) (*multinicv1alpha1.OpenstackConfigSettings, error) {
+	base, err := r.operatorSettings(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return multinicv1alpha1.MergeSettings(base, cfg.Spec.Settings), nil
+}
+
+// teardownSettings는 삭제 경로에서 사용할 settings를 반환한다.
+// OpenstackOperatorConfig를 읽지 못해도 정리는 CR settings만으로 계속 진행한다.
+func (r *OpenstackConfigReconciler) teardownSettings(ctx context.Context, log logr.Logger, cfg *multinicv1alpha1.OpenstackConfig) *multinicv1alpha1.OpenstackConfigSettings {
+	spec, err := r.effectiveSettings(ctx, cfg)
+	if err != nil {
+		log.Error(err, "failed to read operator defaults; using CR settings only")
+		return multinicv1alpha1.MergeSettings(nil, cfg.Spec.Settings)
+	}
+	return spec
+}
+
+// mapOperatorConfig는 OpenstackOperatorConfig 변경 시 이를 참조하는 OpenstackConfig 목록을 반환한다.
+func (r *OpenstackConfigReconciler) mapOperatorConfig(ctx context.Context, obj client.Object) []ctrl.Request {
+	var list multinicv1alpha1.OpenstackConfigList
+	if err := r.List(ctx, &list); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list openstackconfigs for operator config", "operatorConfig", obj.GetName())
+		return nil
+	}
+	var reqs []ctrl.Request
+	for i := range list.Items {
+		cfg := &list.Items[i]
+		if name, _ := operatorConfigName(cfg); name != obj.GetName() {
+			continue
+		}
+		reqs = append(reqs, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.Name}})
+	}
+	return reqs
+}
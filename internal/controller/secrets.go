@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+)
+
+// secretRefIndex는 OpenstackConfig가 참조하는 같은 네임스페이스 Secret 이름 인덱스이다.
+const secretRefIndex = "spec.secretRefs"
+
+// configMapRefIndex는 OpenstackConfig가 참조하는 같은 네임스페이스 ConfigMap 이름 인덱스이다.
+const configMapRefIndex = "spec.configMapRefs"
+
+// secretRefNames는 reconcile 중 읽는 Secret 이름 목록을 반환한다.
+// encrypt key SecretRef가 없으면 기본 Secret(contrabass-encrypt-key)을 읽으므로 함께 포함한다.
+// OpenstackOperatorConfig에서 상속한 TLS 참조는 인덱스에 넣을 수 없으므로 mapInheritedRefs가 따로 찾는다.
+func secretRefNames(cfg *multinicv1alpha1.OpenstackConfig) []string {
+	var names []string
+	if cfg.Spec.Secrets != nil && cfg.Spec.Secrets.ContrabassEncryptKeySecretRef != nil {
+		names = append(names, cfg.Spec.Secrets.ContrabassEncryptKeySecretRef.Name)
+	} else {
+		names = append(names, multinicv1alpha1.DefaultContrabassEncryptKeySecretName)
+	}
+	if cfg.Spec.NodeDiscovery != nil {
+		names = append(names, cfg.Spec.NodeDiscovery.KubeconfigSecretRef.Name)
+	}
+	names = append(names, tlsSecretNames(cfg.Spec.Settings)...)
+	return uniqueTrimmedList(names)
+}
+
+// configMapRefNames는 reconcile 중 읽는 CA bundle ConfigMap 이름 목록을 반환한다.
+func configMapRefNames(cfg *multinicv1alpha1.OpenstackConfig) []string {
+	return tlsConfigMapNames(cfg.Spec.Settings)
+}
+
+// tlsSecretNames는 settings의 TLS 설정이 참조하는 Secret(CA bundle, client 인증서) 이름 목록이다.
+func tlsSecretNames(settings *multinicv1alpha1.OpenstackConfigSettings) []string {
+	var names []string
+	for _, t := range tlsSettingsList(settings) {
+		if t == nil {
+			continue
+		}
+		if t.CABundleRef != nil && caBundleKind(t.CABundleRef) == multinicv1alpha1.CABundleKindSecret {
+			names = append(names, t.CABundleRef.Name)
+		}
+		names = append(names, t.ClientCertSecretName)
+	}
+	return uniqueTrimmedList(names)
+}
+
+// tlsConfigMapNames는 settings의 TLS 설정이 참조하는 CA bundle ConfigMap 이름 목록이다.
+func tlsConfigMapNames(settings *multinicv1alpha1.OpenstackConfigSettings) []string {
+	var names []string
+	for _, t := range tlsSettingsList(settings) {
+		if t != nil && t.CABundleRef != nil && caBundleKind(t.CABundleRef) == multinicv1alpha1.CABundleKindConfigMap {
+			names = append(names, t.CABundleRef.Name)
+		}
+	}
+	return uniqueTrimmedList(names)
+}
+
+// indexSecretRefs는 secretRefIndex 인덱서 함수이다.
+func indexSecretRefs(obj client.Object) []string {
+	cfg, ok := obj.(*multinicv1alpha1.OpenstackConfig)
+	if !ok {
+		return nil
+	}
+	return secretRefNames(cfg)
+}
+
+// indexConfigMapRefs는 configMapRefIndex 인덱서 함수이다.
+func indexConfigMapRefs(obj client.Object) []string {
+	cfg, ok := obj.(*multinicv1alpha1.OpenstackConfig)
+	if !ok {
+		return nil
+	}
+	return configMapRefNames(cfg)
+}
+
+// mapSecret은 Secret 변경 시 이를 참조하는 OpenstackConfig 목록을 반환한다.
+func (r *OpenstackConfigReconciler) mapSecret(ctx context.Context, obj client.Object) []ctrl.Request {
+	return mergeRequests(r.mapIndexedRefs(ctx, secretRefIndex, obj), r.mapInheritedRefs(ctx, obj, tlsSecretNames))
+}
+
+// mapConfigMap은 CA bundle ConfigMap 변경 시 이를 참조하는 OpenstackConfig 목록을 반환한다.
+func (r *OpenstackConfigReconciler) mapConfigMap(ctx context.Context, obj client.Object) []ctrl.Request {
+	return mergeRequests(r.mapIndexedRefs(ctx, configMapRefIndex, obj), r.mapInheritedRefs(ctx, obj, tlsConfigMapNames))
+}
+
+// mapIndexedRefs는 index로 obj를 참조하는 같은 네임스페이스 OpenstackConfig 목록을 반환한다.
+func (r *OpenstackConfigReconciler) mapIndexedRefs(ctx context.Context, index string, obj client.Object) []ctrl.Request {
+	var list multinicv1alpha1.OpenstackConfigList
+	if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace()), client.MatchingFields{index: obj.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list openstackconfigs for referenced object", "index", index, "object", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	reqs := make([]ctrl.Request, 0, len(list.Items))
+	for i := range list.Items {
+		reqs = append(reqs, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: list.Items[i].Namespace, Name: list.Items[i].Name}})
+	}
+	return reqs
+}
+
+// mapInheritedRefs는 OpenstackOperatorConfig settings에서 obj를 참조하고, 이를 상속한(CR에서 덮어쓰지 않은)
+// 같은 네임스페이스 OpenstackConfig 목록을 반환한다. refNames는 settings가 참조하는 이름 목록 함수이다.
+func (r *OpenstackConfigReconciler) mapInheritedRefs(ctx context.Context, obj client.Object, refNames func(*multinicv1alpha1.OpenstackConfigSettings) []string) []ctrl.Request {
+	log := logf.FromContext(ctx)
+	var opList multinicv1alpha1.OpenstackOperatorConfigList
+	if err := r.List(ctx, &opList); err != nil {
+		log.Error(err, "failed to list openstackoperatorconfigs for referenced object", "object", obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	var reqs []ctrl.Request
+	for i := range opList.Items {
+		opCfg := &opList.Items[i]
+		if !slices.Contains(refNames(opCfg.Spec.Settings), obj.GetName()) {
+			continue
+		}
+		var list multinicv1alpha1.OpenstackConfigList
+		if err := r.List(ctx, &list, client.InNamespace(obj.GetNamespace())); err != nil {
+			log.Error(err, "failed to list openstackconfigs for referenced object", "object", obj.GetNamespace()+"/"+obj.GetName())
+			return nil
+		}
+		for j := range list.Items {
+			cfg := &list.Items[j]
+			if name, _ := operatorConfigName(cfg); name != opCfg.Name {
+				continue
+			}
+			if slices.Contains(refNames(multinicv1alpha1.MergeSettings(opCfg.Spec.Settings, cfg.Spec.Settings)), obj.GetName()) {
+				reqs = append(reqs, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: cfg.Namespace, Name: cfg.Name}})
+			}
+		}
+	}
+	return reqs
+}
+
+// mergeRequests는 중복을 제거한 요청 목록을 반환한다.
+func mergeRequests(lists ...[]ctrl.Request) []ctrl.Request {
+	seen := make(map[types.NamespacedName]struct{})
+	var out []ctrl.Request
+	for _, reqs := range lists {
+		for _, req := range reqs {
+			if _, ok := seen[req.NamespacedName]; ok {
+				continue
+			}
+			seen[req.NamespacedName] = struct{}{}
+			out = append(out, req)
+		}
+	}
+	return out
+}
+
+// secretDataChanged는 Secret 갱신 중 data가 바뀐 경우만 통과시킨다. (생성/삭제는 항상 통과)
+func secretDataChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, ok := e.ObjectOld.(*corev1.Secret)
+			if !ok {
+				return true
+			}
+			newSecret, ok := e.ObjectNew.(*corev1.Secret)
+			if !ok {
+				return true
+			}
+			return !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+		},
+	}
+}
+
+// configMapDataChanged는 ConfigMap 갱신 중 data/binaryData가 바뀐 경우만 통과시킨다.
+func configMapDataChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldCM, ok := e.ObjectOld.(*corev1.ConfigMap)
+			if !ok {
+				return true
+			}
+			newCM, ok := e.ObjectNew.(*corev1.ConfigMap)
+			if !ok {
+				return true
+			}
+			return !reflect.DeepEqual(oldCM.Data, newCM.Data) || !reflect.DeepEqual(oldCM.BinaryData, newCM.BinaryData)
+		},
+	}
+}
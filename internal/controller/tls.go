@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+	"multinic-operator/pkg/httptransport"
+)
+
+// 공유 transport 서비스 이름 (service 라벨 값)
+const (
+	transportContrabass = "contrabass"
+	transportOpenstack  = "openstack"
+	transportViola      = "viola"
+)
+
+// tlsMaterial은 읽어 들인 TLS 설정과 그 내용의 지문이다. (공유 transport 키로 사용)
+type tlsMaterial struct {
+	config      *tls.Config
+	fingerprint string
+}
+
+// resolveTLSConfig는 CA bundle/클라이언트 인증서 참조를 읽어 TLS 설정을 만든다.
+// 참조가 없으면 빈 값을 반환해 시스템 CA만 사용한다.
+func (r *OpenstackConfigReconciler) resolveTLSConfig(ctx context.Context, namespace string, spec *multinicv1alpha1.TLSSettings, path string) (tlsMaterial, error) {
+	if spec == nil || (spec.CABundleRef == nil && strings.TrimSpace(spec.ClientCertSecretName) == "") {
+		return tlsMaterial{}, nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	h := sha256.New()
+	if ref := spec.CABundleRef; ref != nil {
+		pem, err := r.readCABundle(ctx, namespace, ref)
+		if err != nil {
+			return tlsMaterial{}, fmt.Errorf("%s.caBundleRef: %w", path, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return tlsMaterial{}, fmt.Errorf("%s.caBundleRef: no PEM certificates found in %s/%s", path, caBundleKind(ref), ref.Name)
+		}
+		cfg.RootCAs = pool
+		h.Write([]byte("ca:"))
+		h.Write(pem)
+	}
+	if name := strings.TrimSpace(spec.ClientCertSecretName); name != "" {
+		cert, err := r.readClientCert(ctx, namespace, name)
+		if err != nil {
+			return tlsMaterial{}, fmt.Errorf("%s.clientCertSecretName: %w", path, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+		h.Write([]byte("cert:"))
+		for _, der := range cert.Certificate {
+			h.Write(der)
+		}
+	}
+	return tlsMaterial{config: cfg, fingerprint: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// sharedTransport는 TLS 내용/insecure/timeout이 같은 클라이언트끼리 연결 풀을 공유하도록
+// 레지스트리의 transport를 반환한다. reconcile마다 TLS handshake를 반복하지 않는다.
+func (r *OpenstackConfigReconciler) sharedTransport(service string, m tlsMaterial, insecure bool, timeout time.Duration) http.RoundTripper {
+	key := httptransport.Key{Service: service, TLS: m.fingerprint, Insecure: insecure, Timeout: timeout}
+	return r.transports.Get(key, m.config)
+}
+
+// readCABundle은 Secret 또는 ConfigMap에서 PEM CA bundle을 읽는다.
+func (r *OpenstackConfigReconciler) readCABundle(ctx context.Context, namespace string, ref *multinicv1alpha1.CABundleRef) ([]byte, error) {
+	name := strings.TrimSpace(ref.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	key := caBundleKey(ref)
+	nn := types.NamespacedName{Namespace: namespace, Name: name}
+	if caBundleKind(ref) == multinicv1alpha1.CABundleKindSecret {
+		var secret corev1.Secret
+		if err := r.Get(ctx, nn, &secret); err != nil {
+			return nil, err
+		}
+		value, ok := secret.Data[key]
+		if !ok || len(value) == 0 {
+			return nil, fmt.Errorf("secret key not found: %s", key)
+		}
+		return value, nil
+	}
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, nn, &cm); err != nil {
+		return nil, err
+	}
+	if value, ok := cm.Data[key]; ok && value != "" {
+		return []byte(value), nil
+	}
+	if value, ok := cm.BinaryData[key]; ok && len(value) > 0 {
+		return value, nil
+	}
+	return nil, fmt.Errorf("configmap key not found: %s", key)
+}
+
+// readClientCert는 kubernetes.io/tls Secret(tls.crt/tls.key)에서 클라이언트 인증서를 읽는다.
+func (r *OpenstackConfigReconciler) readClientCert(ctx context.Context, namespace, name string) (tls.Certificate, error) {
+	var secret corev1.Secret
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &secret); err != nil {
+		return tls.Certificate{}, err
+	}
+	certPEM := secret.Data[corev1.TLSCertKey]
+	keyPEM := secret.Data[corev1.TLSPrivateKeyKey]
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return tls.Certificate{}, fmt.Errorf("secret %s must contain %s and %s", name, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)
+	}
+	return tls.X509KeyPair(certPEM, keyPEM)
+}
+
+// caBundleKind는 참조 종류를 반환한다. (기본값: ConfigMap)
+func caBundleKind(ref *multinicv1alpha1.CABundleRef) string {
+	if strings.TrimSpace(ref.Kind) == multinicv1alpha1.CABundleKindSecret {
+		return multinicv1alpha1.CABundleKindSecret
+	}
+	return multinicv1alpha1.CABundleKindConfigMap
+}
+
+// caBundleKey는 PEM bundle이 담긴 data 키를 반환한다. (기본값: ca.crt)
+func caBundleKey(ref *multinicv1alpha1.CABundleRef) string {
+	if key := strings.TrimSpace(ref.Key); key != "" {
+		return key
+	}
+	return multinicv1alpha1.DefaultCABundleKey
+}
+
+// tlsSettingsList는 CR settings에 지정된 TLS 참조 목록을 반환한다.
+func tlsSettingsList(spec *multinicv1alpha1.OpenstackConfigSettings) []*multinicv1alpha1.TLSSettings {
+	if spec == nil {
+		return nil
+	}
+	return []*multinicv1alpha1.TLSSettings{spec.ContrabassTLS, spec.OpenstackTLS, spec.ViolaTLS}
+}
@@ -0,0 +1,120 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"multinic-operator/pkg/viola"
+)
+
+// 저장소 종류 (INVENTORY_BACKEND 값)
+const (
+	BackendFile     = "file"
+	BackendSQLite   = "sqlite"
+	BackendPostgres = "postgres"
+)
+
+// Record는 providerID+nodeName별 마지막 전송 NodeConfig이다.
+type Record struct {
+	ProviderID     string           `json:"providerId"`
+	NodeName       string           `json:"nodeName"`
+	InstanceID     string           `json:"instanceId"`
+	Owner          string           `json:"owner,omitempty"`
+	Config         viola.NodeConfig `json:"config"`
+	LastConfigHash string           `json:"lastConfigHash"`
+	UpdatedAt      time.Time        `json:"updatedAt"`
+	RemovedAt      *time.Time       `json:"removedAt,omitempty"`
+}
+
+// Revision은 노드 NodeConfig가 바뀔 때마다 추가되는 이력 항목이다.
+type Revision struct {
+	ProviderID string           `json:"providerId"`
+	NodeName   string           `json:"nodeName"`
+	Revision   int64            `json:"revision"`
+	ConfigHash string           `json:"configHash"`
+	Owner      string           `json:"owner,omitempty"`
+	Config     viola.NodeConfig `json:"config"`
+	CreatedAt  time.Time        `json:"createdAt"`
+}
+
+// 이력 보존 기본값.
+const (
+	DefaultHistoryMaxRevisions = 20
+	DefaultHistoryMaxAge       = 30 * 24 * time.Hour
+)
+
+// HistoryRetention은 노드별 이력 보존 한도이다. 0 이하이면 해당 한도를 적용하지 않는다.
+// 한도를 넘어도 레코드가 남아 있는 노드의 최신 이력은 지우지 않는다.
+type HistoryRetention struct {
+	MaxRevisions int
+	MaxAge       time.Duration
+}
+
+// Option은 저장소 생성 옵션이다.
+type Option func(*options)
+
+type options struct {
+	retention HistoryRetention
+}
+
+// WithHistoryRetention은 이력 보존 한도를 지정한다.
+func WithHistoryRetention(retention HistoryRetention) Option {
+	return func(o *options) { o.retention = retention }
+}
+
+func buildOptions(opts []Option) options {
+	o := options{retention: HistoryRetention{MaxRevisions: DefaultHistoryMaxRevisions, MaxAge: DefaultHistoryMaxAge}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Backend는 컨트롤러와 Inventory API가 사용하는 저장소 인터페이스이다.
+type Backend interface {
+	// Init은 저장소를 사용할 수 있게 준비한다. (SQL 저장소는 스키마 마이그레이션 수행)
+	Init(ctx context.Context) error
+	// GetHash는 providerID+nodeName 기준 마지막 해시를 반환한다. 없거나 삭제 표시되었으면 빈 값이다.
+	GetHash(ctx context.Context, providerID, nodeName string) (string, error)
+	// Upsert는 최신 NodeConfig를 저장하고 삭제 표시를 해제한다.
+	// 해시가 마지막 이력과 다르면 이력(Revision)을 추가하고 이 노드의 보존 한도를 넘는 이력을 정리한다.
+	Upsert(ctx context.Context, providerID, owner string, node viola.NodeConfig, hash string, updatedAt time.Time) error
+	// MarkRemoved는 Viola에서 삭제된 노드 레코드에 삭제 시각을 기록한다.
+	MarkRemoved(ctx context.Context, providerID, nodeName string, removedAt time.Time) error
+	// Delete는 providerID+nodeName 레코드를 제거한다. 이력은 보존 기간 동안 남는다.
+	Delete(ctx context.Context, providerID, nodeName string) error
+	// List는 조건(providerID/nodeName/instanceID, 빈 값은 전체)으로 레코드를 조회한다.
+	List(ctx context.Context, providerID, nodeName, instanceID string) ([]Record, error)
+	// History는 providerID+nodeName의 이력을 최신순으로 최대 limit개 반환한다. (0 이하이면 전체)
+	History(ctx context.Context, providerID, nodeName string, limit int) ([]Revision, error)
+	// PruneHistory는 모든 노드에서 보존 기간이 지난 이력을 정리한다. (HistoryPruner가 주기적으로 호출)
+	// 삭제된 노드처럼 더 이상 Upsert되지 않는 노드의 이력도 여기서 정리된다.
+	PruneHistory(ctx context.Context, now time.Time) error
+	// Close는 저장소 자원을 해제한다.
+	Close() error
+}
+
+var (
+	_ Backend = (*FileStore)(nil)
+	_ Backend = (*SQLStore)(nil)
+	_ Querier = (*SQLStore)(nil)
+)
+
+// Open은 kind에 맞는 저장소를 연다. file/sqlite는 path를, postgres는 dsn을 사용한다.
+func Open(kind, path, dsn string, opts ...Option) (Backend, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", BackendFile:
+		return NewFileStore(path, opts...)
+	case BackendSQLite:
+		return NewSQLiteStore(path, opts...)
+	case BackendPostgres:
+		if strings.TrimSpace(dsn) == "" {
+			return nil, fmt.Errorf("inventory dsn is required for postgres backend")
+		}
+		return NewPostgresStore(dsn, opts...)
+	default:
+		return nil, fmt.Errorf("unknown inventory backend %q (file, sqlite, postgres)", kind)
+	}
+}
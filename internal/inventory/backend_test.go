@@ -0,0 +1,213 @@
+package inventory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"multinic-operator/pkg/viola"
+)
+
+// INVENTORY_TEST_POSTGRES_DSN이 지정되면 PostgreSQL 저장소도 같은 시나리오로 검증한다.
+func backends(t *testing.T) map[string]Backend {
+	t.Helper()
+	dir := t.TempDir()
+	file, err := NewFileStore(filepath.Join(dir, "inventory.json"))
+	if err != nil {
+		t.Fatalf("file store: %v", err)
+	}
+	sqlite, err := NewSQLiteStore(filepath.Join(dir, "inventory.db"))
+	if err != nil {
+		t.Fatalf("sqlite store: %v", err)
+	}
+	out := map[string]Backend{BackendFile: file, BackendSQLite: sqlite}
+	if dsn := os.Getenv("INVENTORY_TEST_POSTGRES_DSN"); dsn != "" {
+		pg, err := NewPostgresStore(dsn)
+		if err != nil {
+			t.Fatalf("postgres store: %v", err)
+		}
+		out[BackendPostgres] = pg
+	}
+	return out
+}
+
+func TestBackends_RecordLifecycle(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	node := viola.NodeConfig{
+		NodeName:   "worker-1",
+		InstanceID: "vm-1",
+		Interfaces: []viola.NodeInterface{{ID: 1, PortID: "port-1", MAC: "fa:16:3e:00:00:01"}},
+	}
+
+	for name, store := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			defer store.Close()
+			if err := store.Init(ctx); err != nil {
+				t.Fatalf("init: %v", err)
+			}
+			// 여러 replica가 동시에 기동해도 마이그레이션은 한 번만 적용된다.
+			if err := store.Init(ctx); err != nil {
+				t.Fatalf("re-init: %v", err)
+			}
+			_ = store.Delete(ctx, "provider-1", "worker-1")
+
+			if err := store.Upsert(ctx, "provider-1", "ns/cfg", node, "hash-1", at); err != nil {
+				t.Fatalf("upsert: %v", err)
+			}
+			if err := store.Upsert(ctx, "provider-1", "ns/cfg", node, "hash-2", at.Add(time.Minute)); err != nil {
+				t.Fatalf("upsert again: %v", err)
+			}
+			if hash, err := store.GetHash(ctx, "provider-1", "worker-1"); err != nil || hash != "hash-2" {
+				t.Fatalf("expected hash-2, got %q (%v)", hash, err)
+			}
+
+			records, err := store.List(ctx, "provider-1", "", "vm-1")
+			if err != nil || len(records) != 1 {
+				t.Fatalf("expected 1 record by instance, got %v (%v)", records, err)
+			}
+			rec := records[0]
+			if rec.Owner != "ns/cfg" || rec.Config.Interfaces[0].PortID != "port-1" || !rec.UpdatedAt.Equal(at.Add(time.Minute)) {
+				t.Fatalf("unexpected record: %+v", rec)
+			}
+			if records, _ := store.List(ctx, "provider-2", "", ""); len(records) != 0 {
+				t.Fatalf("expected provider filter to exclude record, got %v", records)
+			}
+
+			if err := store.MarkRemoved(ctx, "provider-1", "worker-1", at.Add(time.Hour)); err != nil {
+				t.Fatalf("mark removed: %v", err)
+			}
+			if hash, _ := store.GetHash(ctx, "provider-1", "worker-1"); hash != "" {
+				t.Fatalf("expected removed record to have no hash, got %q", hash)
+			}
+			records, _ = store.List(ctx, "provider-1", "worker-1", "")
+			if len(records) != 1 || records[0].RemovedAt == nil || !records[0].RemovedAt.Equal(at.Add(time.Hour)) {
+				t.Fatalf("expected removedAt to be recorded, got %v", records)
+			}
+
+			if err := store.Delete(ctx, "provider-1", "worker-1"); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if records, _ := store.List(ctx, "", "", ""); len(records) != 0 {
+				t.Fatalf("expected no records after delete, got %v", records)
+			}
+		})
+	}
+}
+
+func TestBackends_HistoryRetention(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	node := func(mtu int) viola.NodeConfig {
+		return viola.NodeConfig{NodeName: "worker-1", Interfaces: []viola.NodeInterface{{ID: 0, PortID: "port-1", MTU: mtu}}}
+	}
+	retention := WithHistoryRetention(HistoryRetention{MaxRevisions: 3, MaxAge: 24 * time.Hour})
+
+	dir := t.TempDir()
+	file, _ := NewFileStore(filepath.Join(dir, "inventory.json"), retention)
+	sqlite, _ := NewSQLiteStore(filepath.Join(dir, "inventory.db"), retention)
+	for name, store := range map[string]Backend{BackendFile: file, BackendSQLite: sqlite} {
+		t.Run(name, func(t *testing.T) {
+			defer store.Close()
+			if err := store.Init(ctx); err != nil {
+				t.Fatalf("init: %v", err)
+			}
+			for i, hash := range []string{"h1", "h1", "h2", "h3", "h4"} {
+				if err := store.Upsert(ctx, "p", "", node(1400+i), hash, at.Add(time.Duration(i)*time.Minute)); err != nil {
+					t.Fatalf("upsert %s: %v", hash, err)
+				}
+			}
+			// 같은 해시는 이력을 추가하지 않고, 개수 한도(3)를 넘는 오래된 이력은 제거된다.
+			revs, err := store.History(ctx, "p", "worker-1", 0)
+			if err != nil || len(revs) != 3 {
+				t.Fatalf("expected 3 revisions, got %v (%v)", revs, err)
+			}
+			if revs[0].Revision != 4 || revs[0].ConfigHash != "h4" || revs[2].Revision != 2 || revs[0].Config.Interfaces[0].MTU != 1404 {
+				t.Fatalf("unexpected revisions (newest first): %+v", revs)
+			}
+			if revs, _ := store.History(ctx, "p", "worker-1", 1); len(revs) != 1 || revs[0].Revision != 4 {
+				t.Fatalf("expected limit to return latest revision, got %+v", revs)
+			}
+
+			// 다른 노드의 저장은 이 노드의 이력을 건드리지 않고, 전체 정리는 PruneHistory가 한다.
+			later := at.Add(48 * time.Hour)
+			if err := store.Upsert(ctx, "p", "", viola.NodeConfig{NodeName: "worker-2"}, "x", later); err != nil {
+				t.Fatalf("upsert worker-2: %v", err)
+			}
+			if revs, _ := store.History(ctx, "p", "worker-1", 0); len(revs) != 3 {
+				t.Fatalf("expected other node upsert to leave history alone, got %+v", revs)
+			}
+			// 기간 한도를 넘어도 레코드가 남아 있는 노드의 최신 이력은 유지된다.
+			if err := store.PruneHistory(ctx, later); err != nil {
+				t.Fatalf("prune: %v", err)
+			}
+			if revs, _ := store.History(ctx, "p", "worker-1", 0); len(revs) != 1 || revs[0].Revision != 4 {
+				t.Fatalf("expected only latest revision to survive max age, got %+v", revs)
+			}
+
+			// 같은 노드를 저장할 때는 그 노드의 기간 지난 이력을 바로 정리한다.
+			if err := store.Upsert(ctx, "p", "", viola.NodeConfig{NodeName: "worker-2"}, "y", later.Add(25*time.Hour)); err != nil {
+				t.Fatalf("upsert worker-2 again: %v", err)
+			}
+			if revs, _ := store.History(ctx, "p", "worker-2", 0); len(revs) != 1 || revs[0].ConfigHash != "y" {
+				t.Fatalf("expected expired revision of upserted node to be pruned, got %+v", revs)
+			}
+
+			// 삭제된 노드의 이력은 기간 한도가 지나면 모두 제거된다.
+			if err := store.Delete(ctx, "p", "worker-1"); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+			if revs, _ := store.History(ctx, "p", "worker-1", 0); len(revs) != 1 {
+				t.Fatalf("expected history to outlive record deletion, got %+v", revs)
+			}
+			if err := store.PruneHistory(ctx, later.Add(time.Minute)); err != nil {
+				t.Fatalf("prune: %v", err)
+			}
+			if revs, _ := store.History(ctx, "p", "worker-1", 0); len(revs) != 0 {
+				t.Fatalf("expected expired history of deleted node to be pruned, got %+v", revs)
+			}
+		})
+	}
+}
+
+func TestSQLiteStore_MigrationsPersist(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "inventory.db")
+	store, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if err := store.Upsert(ctx, "p", "", viola.NodeConfig{NodeName: "n"}, "h", time.Now()); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+	store.Close()
+
+	reopened, err := NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer reopened.Close()
+	if err := reopened.Init(ctx); err != nil {
+		t.Fatalf("re-init: %v", err)
+	}
+	if version, err := reopened.SchemaVersion(ctx); err != nil || version != len(sqliteDialect.migrations) {
+		t.Fatalf("expected schema version %d, got %d (%v)", len(sqliteDialect.migrations), version, err)
+	}
+	if hash, _ := reopened.GetHash(ctx, "p", "n"); hash != "h" {
+		t.Fatalf("expected data to survive reopen, got %q", hash)
+	}
+}
+
+func TestOpen_UnknownBackend(t *testing.T) {
+	if _, err := Open("mysql", "", ""); err == nil {
+		t.Fatalf("expected error for unknown backend")
+	}
+	if _, err := Open(BackendPostgres, "", ""); err == nil {
+		t.Fatalf("expected error for postgres without dsn")
+	}
+}
@@ -0,0 +1,43 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// DefaultHistoryPruneInterval은 보존 기간이 지난 이력을 전체 정리하는 기본 주기이다.
+const DefaultHistoryPruneInterval = time.Hour
+
+// HistoryPruner는 Backend.PruneHistory를 주기적으로 실행하는 manager.Runnable이다.
+// Upsert는 해당 노드만 정리하므로, 변경이 없는 노드와 삭제된 노드의 이력은 여기서 정리된다.
+type HistoryPruner struct {
+	log      logr.Logger
+	store    Backend
+	interval time.Duration
+}
+
+// NewHistoryPruner는 interval마다 이력을 정리하는 HistoryPruner를 만든다. (0 이하이면 기본값)
+func NewHistoryPruner(log logr.Logger, store Backend, interval time.Duration) *HistoryPruner {
+	if interval <= 0 {
+		interval = DefaultHistoryPruneInterval
+	}
+	return &HistoryPruner{log: log, store: store, interval: interval}
+}
+
+// Start는 manager.Runnable 구현이다. 시작 직후 한 번 정리한 뒤 interval마다 반복한다.
+func (p *HistoryPruner) Start(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if err := p.store.PruneHistory(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			p.log.Error(err, "inventory history prune failed")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
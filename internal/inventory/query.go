@@ -0,0 +1,273 @@
+package inventory
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 정렬 기준 (sort 쿼리, "-" 접두사는 내림차순)
+const (
+	SortNodeName   = "nodeName"
+	SortInstanceID = "instanceId"
+	SortUpdatedAt  = "updatedAt"
+)
+
+// ErrInvalidContinue는 continue 토큰이 손상되었거나 다른 조건으로 발급되었을 때 반환된다.
+var ErrInvalidContinue = errors.New("invalid continue token; restart listing without continue")
+
+// Querier는 Query를 저장소에서 직접 평가하는 Backend이다. (SQLStore)
+// 구현하지 않는 저장소는 List 결과를 Query.Apply로 메모리에서 평가한다.
+type Querier interface {
+	Query(ctx context.Context, q Query) (page []Record, total int, next string, err error)
+}
+
+// RunQuery는 backend가 Querier이면 저장소에서, 아니면 메모리에서 q를 평가한다.
+// 잘못된 continue 토큰은 ErrInvalidContinue이다.
+func RunQuery(ctx context.Context, backend Backend, q Query) (page []Record, total int, next string, err error) {
+	if querier, ok := backend.(Querier); ok {
+		return querier.Query(ctx, q)
+	}
+	records, err := backend.List(ctx, q.ProviderID, q.NodeName, q.InstanceID)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	return q.Apply(records)
+}
+
+// Query는 Inventory 목록 조회 조건이다. 빈 값은 조건을 적용하지 않는다.
+// 인터페이스(MAC/IP/네트워크) 조건은 NodeConfig 안의 값이므로 SQL 저장소에서도 메모리에서 평가한다.
+type Query struct {
+	ProviderID     string
+	NodeName       string
+	NodeNamePrefix string
+	InstanceID     string
+	// MAC은 대소문자를 구분하지 않는다.
+	MAC string
+	// IP는 주소 또는 CIDR이다. 주소이면 같은 주소이거나 인터페이스 CIDR에 포함되는 노드를,
+	// CIDR이면 주소가 그 대역에 속하는 인터페이스가 있는 노드를 찾는다.
+	IP           string
+	NetworkID    string
+	SubnetID     string
+	UpdatedAfter time.Time
+	// IncludeRemoved는 삭제 표시(RemovedAt)된 레코드도 포함할지 여부이다. (기본 제외)
+	IncludeRemoved bool
+	// Sort는 정렬 기준이다. (기본 nodeName) 같은 값은 nodeName, providerId 순으로 정렬해 순서가 항상 같다.
+	Sort       string
+	Descending bool
+	// Limit은 한 번에 반환할 최대 개수이다. (0이면 전체)
+	Limit int
+	// Continue는 이전 응답의 다음 페이지 토큰이다.
+	Continue string
+
+	ipAddr   netip.Addr
+	ipPrefix netip.Prefix
+}
+
+// ParseQuery는 HTTP 쿼리 파라미터를 Query로 변환한다.
+func ParseQuery(values url.Values) (Query, error) {
+	q := Query{
+		ProviderID:     values.Get("providerId"),
+		NodeName:       values.Get("nodeName"),
+		NodeNamePrefix: values.Get("nodeNamePrefix"),
+		InstanceID:     values.Get("instanceId"),
+		MAC:            strings.ToLower(strings.TrimSpace(values.Get("mac"))),
+		IP:             strings.TrimSpace(values.Get("ip")),
+		NetworkID:      values.Get("networkId"),
+		SubnetID:       values.Get("subnetId"),
+		Sort:           SortNodeName,
+		Continue:       values.Get("continue"),
+	}
+	if raw := values.Get("sort"); raw != "" {
+		q.Sort, q.Descending = strings.CutPrefix(raw, "-")
+		switch q.Sort {
+		case SortNodeName, SortInstanceID, SortUpdatedAt:
+		default:
+			return Query{}, fmt.Errorf("sort must be one of %s, %s, %s (prefix - for descending)", SortNodeName, SortInstanceID, SortUpdatedAt)
+		}
+	}
+	if raw := values.Get("updatedAfter"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return Query{}, fmt.Errorf("updatedAfter must be RFC3339: %w", err)
+		}
+		q.UpdatedAfter = t
+	}
+	if raw := values.Get("includeRemoved"); raw != "" {
+		include, err := strconv.ParseBool(raw)
+		if err != nil {
+			return Query{}, fmt.Errorf("includeRemoved must be a boolean")
+		}
+		q.IncludeRemoved = include
+	}
+	if raw := values.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n <= 0 {
+			return Query{}, fmt.Errorf("limit must be a positive integer")
+		}
+		q.Limit = n
+	}
+	if q.IP != "" {
+		if strings.Contains(q.IP, "/") {
+			prefix, err := netip.ParsePrefix(q.IP)
+			if err != nil {
+				return Query{}, fmt.Errorf("ip must be an address or CIDR: %w", err)
+			}
+			q.ipPrefix = prefix.Masked()
+		} else {
+			addr, err := netip.ParseAddr(q.IP)
+			if err != nil {
+				return Query{}, fmt.Errorf("ip must be an address or CIDR: %w", err)
+			}
+			q.ipAddr = addr
+		}
+	}
+	return q, nil
+}
+
+// Apply는 records를 조건으로 거르고 정렬한 뒤 한 페이지를 반환한다.
+// total은 페이지 적용 전 전체 일치 수, next는 다음 페이지 토큰(마지막이면 빈 값)이다.
+func (q Query) Apply(records []Record) (page []Record, total int, next string, err error) {
+	matched := make([]Record, 0, len(records))
+	for _, rec := range records {
+		if q.matches(rec) {
+			matched = append(matched, rec)
+		}
+	}
+	slices.SortFunc(matched, q.compare)
+	total = len(matched)
+
+	if q.Continue != "" {
+		after, err := q.decodeContinue()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		// 마지막으로 반환한 레코드 다음부터 이어간다. (그 사이 추가/삭제되어도 중복/누락 없음)
+		start, _ := slices.BinarySearchFunc(matched, after, q.compare)
+		if start < len(matched) && q.compare(matched[start], after) == 0 {
+			start++
+		}
+		matched = matched[start:]
+	}
+	if q.Limit > 0 && len(matched) > q.Limit {
+		matched = matched[:q.Limit]
+		next = q.encodeContinue(matched[len(matched)-1])
+	}
+	return matched, total, next, nil
+}
+
+func (q Query) matches(rec Record) bool {
+	switch {
+	case q.ProviderID != "" && rec.ProviderID != q.ProviderID,
+		q.NodeName != "" && rec.NodeName != q.NodeName,
+		q.NodeNamePrefix != "" && !strings.HasPrefix(rec.NodeName, q.NodeNamePrefix),
+		q.InstanceID != "" && rec.InstanceID != q.InstanceID,
+		!q.UpdatedAfter.IsZero() && !rec.UpdatedAt.After(q.UpdatedAfter),
+		!q.IncludeRemoved && rec.RemovedAt != nil:
+		return false
+	}
+	if !q.hasInterfaceFilter() {
+		return true
+	}
+	// 인터페이스 조건은 같은 인터페이스가 모두 만족해야 한다.
+	for _, iface := range rec.Config.Interfaces {
+		if q.MAC != "" && strings.ToLower(iface.MAC) != q.MAC {
+			continue
+		}
+		if q.NetworkID != "" && iface.NetworkID != q.NetworkID {
+			continue
+		}
+		if q.SubnetID != "" && iface.SubnetID != q.SubnetID {
+			continue
+		}
+		if q.IP != "" && !q.matchesIP(iface.Address, iface.CIDR) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func (q Query) hasInterfaceFilter() bool {
+	return q.MAC != "" || q.IP != "" || q.NetworkID != "" || q.SubnetID != ""
+}
+
+func (q Query) matchesIP(address, cidr string) bool {
+	addr, err := netip.ParseAddr(address)
+	if q.ipPrefix.IsValid() {
+		return err == nil && q.ipPrefix.Contains(addr)
+	}
+	if err == nil && addr == q.ipAddr {
+		return true
+	}
+	prefix, err := netip.ParsePrefix(cidr)
+	return err == nil && prefix.Contains(q.ipAddr)
+}
+
+// compare는 정렬 기준 값, nodeName, providerId 순으로 비교한다. (providerId+nodeName은 유일)
+func (q Query) compare(a, b Record) int {
+	var c int
+	switch q.Sort {
+	case SortInstanceID:
+		c = cmp.Compare(a.InstanceID, b.InstanceID)
+	case SortUpdatedAt:
+		c = a.UpdatedAt.Compare(b.UpdatedAt)
+	}
+	c = cmp.Or(c, cmp.Compare(a.NodeName, b.NodeName), cmp.Compare(a.ProviderID, b.ProviderID))
+	if q.Descending {
+		return -c
+	}
+	return c
+}
+
+// continueToken은 마지막으로 반환한 레코드의 정렬 키와 조회 조건 지문이다.
+type continueToken struct {
+	ProviderID string    `json:"p"`
+	NodeName   string    `json:"n"`
+	InstanceID string    `json:"i,omitempty"`
+	UpdatedAt  time.Time `json:"u"`
+	Query      string    `json:"q"`
+}
+
+func (q Query) encodeContinue(last Record) string {
+	raw, _ := json.Marshal(continueToken{
+		ProviderID: last.ProviderID,
+		NodeName:   last.NodeName,
+		InstanceID: last.InstanceID,
+		UpdatedAt:  last.UpdatedAt,
+		Query:      q.fingerprint(),
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func (q Query) decodeContinue() (Record, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(q.Continue)
+	if err != nil {
+		return Record{}, ErrInvalidContinue
+	}
+	var token continueToken
+	if err := json.Unmarshal(raw, &token); err != nil || token.Query != q.fingerprint() {
+		return Record{}, ErrInvalidContinue
+	}
+	return Record{ProviderID: token.ProviderID, NodeName: token.NodeName, InstanceID: token.InstanceID, UpdatedAt: token.UpdatedAt}, nil
+}
+
+// fingerprint는 limit/continue를 제외한 조회 조건의 지문이다. 조건이 바뀌면 토큰을 거부한다.
+func (q Query) fingerprint() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		q.ProviderID, q.NodeName, q.NodeNamePrefix, q.InstanceID, q.MAC, q.IP, q.NetworkID, q.SubnetID,
+		q.UpdatedAfter.UTC().Format(time.RFC3339Nano), strconv.FormatBool(q.IncludeRemoved), q.Sort, strconv.FormatBool(q.Descending),
+	}, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
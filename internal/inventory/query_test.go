@@ -0,0 +1,187 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"multinic-operator/pkg/viola"
+)
+
+func queryRecords() []Record {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	records := make([]Record, 0, 6)
+	for i := 5; i >= 0; i-- {
+		records = append(records, Record{
+			ProviderID: "p",
+			NodeName:   fmt.Sprintf("worker-%d", i),
+			InstanceID: fmt.Sprintf("vm-%d", 5-i),
+			UpdatedAt:  at.Add(time.Duration(i%3) * time.Minute),
+			Config: viola.NodeConfig{Interfaces: []viola.NodeInterface{{
+				MAC:       fmt.Sprintf("FA:16:3E:00:00:0%d", i),
+				Address:   fmt.Sprintf("10.0.%d.5", i),
+				CIDR:      fmt.Sprintf("10.0.%d.0/24", i),
+				NetworkID: fmt.Sprintf("net-%d", i%2),
+				SubnetID:  fmt.Sprintf("subnet-%d", i),
+			}}},
+		})
+	}
+	removedAt := at.Add(time.Hour)
+	records = append(records,
+		Record{ProviderID: "other", NodeName: "infra-0"},
+		Record{ProviderID: "p", NodeName: "worker-9", InstanceID: "vm-9", UpdatedAt: at, RemovedAt: &removedAt},
+	)
+	return records
+}
+
+func applyQuery(t *testing.T, raw string) ([]string, int, string) {
+	t.Helper()
+	values, _ := url.ParseQuery(raw)
+	q, err := ParseQuery(values)
+	if err != nil {
+		t.Fatalf("%s: parse: %v", raw, err)
+	}
+	page, total, next, err := q.Apply(queryRecords())
+	if err != nil {
+		t.Fatalf("%s: apply: %v", raw, err)
+	}
+	names := make([]string, 0, len(page))
+	for _, rec := range page {
+		names = append(names, rec.NodeName)
+	}
+	return names, total, next
+}
+
+func TestQuery_Filters(t *testing.T) {
+	for raw, want := range map[string]string{
+		"":                                            "[infra-0 worker-0 worker-1 worker-2 worker-3 worker-4 worker-5]",
+		"providerId=p&nodeNamePrefix=worker":          "[worker-0 worker-1 worker-2 worker-3 worker-4 worker-5]",
+		"mac=fa:16:3e:00:00:03":                       "[worker-3]",
+		"ip=10.0.2.5":                                 "[worker-2]",
+		"ip=10.0.4.77":                                "[worker-4]",
+		"ip=10.0.0.0/22":                              "[worker-0 worker-1 worker-2 worker-3]",
+		"networkId=net-1":                             "[worker-1 worker-3 worker-5]",
+		"networkId=net-1&subnetId=subnet-3":           "[worker-3]",
+		"networkId=net-1&subnetId=subnet-2":           "[]",
+		"updatedAfter=2026-01-02T03:05:05Z":           "[worker-2 worker-5]",
+		"providerId=p&sort=-instanceId":               "[worker-0 worker-1 worker-2 worker-3 worker-4 worker-5]",
+		"providerId=p&sort=updatedAt":                 "[worker-0 worker-3 worker-1 worker-4 worker-2 worker-5]",
+		"nodeNamePrefix=worker-9":                     "[]",
+		"nodeNamePrefix=worker-9&includeRemoved=true": "[worker-9]",
+	} {
+		if got, _, _ := applyQuery(t, raw); fmt.Sprint(got) != want {
+			t.Fatalf("%q: expected %s, got %v", raw, want, got)
+		}
+	}
+
+	for _, raw := range []string{"sort=mac", "limit=0", "ip=10.0.0", "updatedAfter=yesterday", "includeRemoved=maybe"} {
+		values, _ := url.ParseQuery(raw)
+		if _, err := ParseQuery(values); err == nil {
+			t.Fatalf("%q: expected parse error", raw)
+		}
+	}
+}
+
+func TestQuery_Pagination(t *testing.T) {
+	base := "providerId=p&sort=-updatedAt&limit=4"
+	page, total, next := applyQuery(t, base)
+	if fmt.Sprint(page) != "[worker-5 worker-2 worker-4 worker-1]" || total != 6 || next == "" {
+		t.Fatalf("unexpected first page: %v total=%d next=%q", page, total, next)
+	}
+	page, _, last := applyQuery(t, base+"&continue="+next)
+	if fmt.Sprint(page) != "[worker-3 worker-0]" || last != "" {
+		t.Fatalf("unexpected second page: %v next=%q", page, last)
+	}
+
+	// 다음 페이지 사이에 앞쪽 레코드가 사라져도 이어서 조회한다.
+	values, _ := url.ParseQuery(base + "&continue=" + next)
+	q, _ := ParseQuery(values)
+	records := queryRecords()
+	records = append(records[:0:0], records[1:]...) // worker-5 삭제
+	if page, _, _, err := q.Apply(records); err != nil || len(page) != 2 || page[0].NodeName != "worker-3" {
+		t.Fatalf("expected continue to survive removal, got %v (%v)", page, err)
+	}
+
+	// 다른 조건으로 발급된 토큰은 거부한다.
+	values, _ = url.ParseQuery("providerId=p&sort=nodeName&limit=4&continue=" + next)
+	q, _ = ParseQuery(values)
+	if _, _, _, err := q.Apply(queryRecords()); !errors.Is(err, ErrInvalidContinue) {
+		t.Fatalf("expected invalid continue, got %v", err)
+	}
+}
+
+func TestSQLStore_QueryMatchesApply(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "inventory.db"))
+	if err != nil {
+		t.Fatalf("sqlite store: %v", err)
+	}
+	defer store.Close()
+	if err := store.Init(ctx); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	records := queryRecords()
+	for _, rec := range records {
+		node := rec.Config
+		node.NodeName, node.InstanceID = rec.NodeName, rec.InstanceID
+		if err := store.Upsert(ctx, rec.ProviderID, "", node, "h", rec.UpdatedAt); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+		if rec.RemovedAt != nil {
+			if err := store.MarkRemoved(ctx, rec.ProviderID, rec.NodeName, *rec.RemovedAt); err != nil {
+				t.Fatalf("mark removed: %v", err)
+			}
+		}
+	}
+
+	// SQL로 평가한 결과와 페이지 토큰은 메모리 평가(Apply)와 같아야 한다.
+	for _, raw := range []string{
+		"",
+		"includeRemoved=true",
+		"providerId=p&nodeNamePrefix=worker-",
+		"nodeNamePrefix=WORKER",
+		"updatedAfter=2026-01-02T03:05:05Z",
+		"networkId=net-1&sort=-updatedAt&limit=2",
+		"providerId=p&sort=-updatedAt&limit=4",
+		"sort=instanceId&limit=3&includeRemoved=true",
+		"sort=-nodeName&limit=2",
+	} {
+		values, _ := url.ParseQuery(raw)
+		q, err := ParseQuery(values)
+		if err != nil {
+			t.Fatalf("%q: parse: %v", raw, err)
+		}
+		for page := 0; ; page++ {
+			got, gotTotal, gotNext, err := RunQuery(ctx, store, q)
+			if err != nil {
+				t.Fatalf("%q page %d: query: %v", raw, page, err)
+			}
+			want, wantTotal, wantNext, _ := q.Apply(records)
+			if nodeNames(got) != nodeNames(want) || gotTotal != wantTotal || gotNext != wantNext {
+				t.Fatalf("%q page %d: expected %s total=%d next=%q, got %s total=%d next=%q",
+					raw, page, nodeNames(want), wantTotal, wantNext, nodeNames(got), gotTotal, gotNext)
+			}
+			if gotNext == "" {
+				break
+			}
+			q.Continue = gotNext
+		}
+	}
+
+	q := Query{Sort: SortNodeName, Limit: 2, Continue: "bogus"}
+	if _, _, _, err := RunQuery(ctx, store, q); !errors.Is(err, ErrInvalidContinue) {
+		t.Fatalf("expected invalid continue, got %v", err)
+	}
+}
+
+func nodeNames(records []Record) string {
+	names := make([]string, 0, len(records))
+	for _, rec := range records {
+		names = append(names, rec.ProviderID+"/"+rec.NodeName)
+	}
+	return fmt.Sprint(names)
+}
@@ -3,10 +3,17 @@ package inventory
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"multinic-operator/pkg/viola"
 )
 
 const violaOpenAPISpec = `openapi: 3.0.3
@@ -46,6 +53,35 @@ paths:
           description: 요청 오류
         "500":
           description: kubectl apply 실패
+    delete:
+      tags: ["viola"]
+      summary: MultiNicNodeConfig 삭제
+      description: |
+        Operator가 CR 삭제/노드 제외 시 더 이상 관리하지 않는 노드를 전달합니다.
+      parameters:
+        - name: x-provider-id
+          in: header
+          required: true
+          schema:
+            type: string
+          description: Viola 라우팅용 provider 식별자 (필수)
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: array
+              items:
+                $ref: "#/components/schemas/NodeRef"
+      responses:
+        "200":
+          description: 삭제 완료
+        "404":
+          description: 대상 없음 (Operator는 삭제 완료로 간주)
+        "400":
+          description: 요청 오류
+        "500":
+          description: kubectl delete 실패
   /v1/interfaces/providers:
     get:
       tags: ["interfaces"]
@@ -64,14 +100,86 @@ paths:
   /v1/interfaces/node-configs:
     get:
       tags: ["interfaces"]
-      summary: 특정 클러스터 전체 노드 인터페이스 조회
+      summary: 노드 인터페이스 조회 (필터/정렬/페이지)
+      description: |
+        조건에 맞는 노드를 정렬해 반환합니다. limit을 지정하면 다음 페이지 토큰을 X-Continue 헤더로 반환하며,
+        같은 조건에 continue로 전달하면 이어서 조회합니다. (조건이 바뀌면 400)
       parameters:
         - name: providerId
           in: query
-          required: true
+          required: false
           schema:
             type: string
-          description: k8sProviderID
+          description: k8sProviderID (권장)
+        - name: nodeName
+          in: query
+          required: false
+          schema:
+            type: string
+        - name: nodeNamePrefix
+          in: query
+          required: false
+          schema:
+            type: string
+        - name: instanceId
+          in: query
+          required: false
+          schema:
+            type: string
+        - name: mac
+          in: query
+          required: false
+          schema:
+            type: string
+          description: 인터페이스 MAC (대소문자 무시)
+        - name: ip
+          in: query
+          required: false
+          schema:
+            type: string
+          description: 주소(인터페이스 주소 일치 또는 인터페이스 CIDR 포함) 또는 CIDR(대역 내 주소)
+        - name: networkId
+          in: query
+          required: false
+          schema:
+            type: string
+        - name: subnetId
+          in: query
+          required: false
+          schema:
+            type: string
+        - name: updatedAfter
+          in: query
+          required: false
+          schema:
+            type: string
+            format: date-time
+        - name: includeRemoved
+          in: query
+          required: false
+          schema:
+            type: boolean
+            default: false
+          description: 삭제 표시(removedAt)된 노드도 포함 (기본 제외)
+        - name: sort
+          in: query
+          required: false
+          schema:
+            type: string
+            enum: ["nodeName", "-nodeName", "instanceId", "-instanceId", "updatedAt", "-updatedAt"]
+            default: nodeName
+        - name: limit
+          in: query
+          required: false
+          schema:
+            type: integer
+            minimum: 1
+        - name: continue
+          in: query
+          required: false
+          schema:
+            type: string
+          description: 이전 응답의 X-Continue 값
       responses:
         "200":
           description: 조회 성공
@@ -81,8 +189,58 @@ paths:
                 type: array
                 items:
                   $ref: "#/components/schemas/InventoryRecord"
+          headers:
+            X-Resource-Version:
+              schema:
+                type: string
+              description: 목록 조회 시점의 watch resourceVersion (이 값으로 /v1/interfaces/watch 재개)
+            X-Total-Count:
+              schema:
+                type: integer
+              description: 페이지 적용 전 조건에 맞는 전체 노드 수
+            X-Continue:
+              schema:
+                type: string
+              description: 다음 페이지 토큰 (마지막 페이지이면 없음)
+        "400":
+          description: 요청 오류 (잘못된 조건/continue 토큰)
         "503":
           description: inventory 저장소 비활성
+  /v1/interfaces/watch:
+    get:
+      tags: ["interfaces"]
+      summary: 노드 인터페이스 변경 스트림 (SSE)
+      description: |
+        inventory 변경을 text/event-stream으로 전달합니다. 이벤트 id는 resourceVersion이며 event는 upserted/removed/deleted입니다.
+        resourceVersion 쿼리 또는 Last-Event-ID 헤더로 이어받을 수 있고, 생략하면 연결 시점 이후 변경만 전달합니다.
+        resourceVersion은 "<epoch>.<n>" 형식이며 epoch은 프로세스마다 달라 재시작 전 값으로 재개하면 410을 반환합니다.
+        이벤트는 변경을 처리한 leader replica에서만 발행되므로 leader가 아닌 replica는 503을 반환합니다.
+      parameters:
+        - name: providerId
+          in: query
+          required: false
+          schema:
+            type: string
+          description: k8sProviderID 필터 (생략 시 전체)
+        - name: resourceVersion
+          in: query
+          required: false
+          schema:
+            type: string
+            example: 3f9a1c2b7d4e.42
+      responses:
+        "200":
+          description: 스트림 시작
+          content:
+            text/event-stream:
+              schema:
+                $ref: "#/components/schemas/WatchEvent"
+        "400":
+          description: 요청 오류
+        "410":
+          description: resourceVersion 만료 (목록 재조회 후 다시 watch)
+        "503":
+          description: inventory 저장소/watch 비활성 또는 leader가 아닌 replica
   /v1/interfaces/node-configs/by-instance/{instanceId}:
     get:
       tags: ["interfaces"]
@@ -112,6 +270,90 @@ paths:
           description: not found
         "503":
           description: inventory 저장소 비활성
+  /v1/interfaces/node-configs/{nodeName}/history:
+    get:
+      tags: ["interfaces"]
+      summary: 노드 NodeConfig 변경 이력 조회
+      description: |
+        NodeConfig가 바뀔 때마다 기록된 이력을 최신순으로 반환합니다. 보존 한도(개수/기간)를 넘은 이력은 제거됩니다.
+      parameters:
+        - name: nodeName
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: providerId
+          in: query
+          required: true
+          schema:
+            type: string
+          description: k8sProviderID
+        - name: limit
+          in: query
+          required: false
+          schema:
+            type: integer
+            minimum: 1
+          description: 최대 반환 개수 (생략 시 보존된 전체)
+      responses:
+        "200":
+          description: 조회 성공
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Revision"
+        "400":
+          description: 요청 오류
+        "404":
+          description: not found
+        "503":
+          description: inventory 저장소 비활성
+  /v1/interfaces/node-configs/{nodeName}/diff:
+    get:
+      tags: ["interfaces"]
+      summary: 두 이력 사이의 인터페이스 변경 조회
+      description: |
+        인터페이스를 portId(없으면 macAddress) 기준으로 대응시켜 추가/제거/변경(name, address, cidr, mtu 등)을 반환합니다.
+        to를 생략하면 최신 이력, from을 생략하면 to 직전 이력과 비교합니다. (직전 이력이 없으면 전체가 추가로 표시됨)
+      parameters:
+        - name: nodeName
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: providerId
+          in: query
+          required: true
+          schema:
+            type: string
+          description: k8sProviderID
+        - name: from
+          in: query
+          required: false
+          schema:
+            type: integer
+            minimum: 1
+        - name: to
+          in: query
+          required: false
+          schema:
+            type: integer
+            minimum: 1
+      responses:
+        "200":
+          description: 조회 성공
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/RevisionDiff"
+        "400":
+          description: 요청 오류
+        "404":
+          description: 이력 없음 (보존 한도로 제거된 revision 포함)
+        "503":
+          description: inventory 저장소 비활성
 components:
   schemas:
     NodeConfig:
@@ -129,6 +371,15 @@ components:
           type: array
           items:
             $ref: "#/components/schemas/NodeInterface"
+    NodeRef:
+      type: object
+      required:
+        - nodeName
+      properties:
+        nodeName:
+          type: string
+        instanceId:
+          type: string
     NodeInterface:
       type: object
       required:
@@ -170,6 +421,103 @@ components:
         updatedAt:
           type: string
           format: date-time
+        owner:
+          type: string
+          description: 전송 주체 OpenstackConfig (namespace/name)
+        removedAt:
+          type: string
+          format: date-time
+          description: Viola에서 삭제된 시각 (삭제된 노드만 존재)
+    Revision:
+      type: object
+      properties:
+        providerId:
+          type: string
+        nodeName:
+          type: string
+        revision:
+          type: integer
+          description: 노드별 1부터 증가하는 이력 번호
+        configHash:
+          type: string
+        owner:
+          type: string
+        config:
+          $ref: "#/components/schemas/NodeConfig"
+        createdAt:
+          type: string
+          format: date-time
+    WatchEvent:
+      type: object
+      properties:
+        type:
+          type: string
+          enum: ["upserted", "removed", "deleted"]
+        resourceVersion:
+          type: string
+        providerId:
+          type: string
+        nodeName:
+          type: string
+        record:
+          $ref: "#/components/schemas/InventoryRecord"
+    RevisionDiff:
+      type: object
+      properties:
+        providerId:
+          type: string
+        nodeName:
+          type: string
+        fromRevision:
+          type: integer
+          description: 비교 기준 revision (0이면 이전 이력 없음)
+        toRevision:
+          type: integer
+        summary:
+          type: string
+          example: "multinic2 mtu 1500→9000; +multinic3 10.0.5.5/24"
+        diff:
+          $ref: "#/components/schemas/NodeConfigDiff"
+    NodeConfigDiff:
+      type: object
+      properties:
+        nodeName:
+          type: string
+        instanceId:
+          $ref: "#/components/schemas/FieldChange"
+        interfaces:
+          type: array
+          items:
+            $ref: "#/components/schemas/InterfaceChange"
+    InterfaceChange:
+      type: object
+      properties:
+        type:
+          type: string
+          enum: ["added", "removed", "modified"]
+        portId:
+          type: string
+        macAddress:
+          type: string
+        name:
+          type: string
+        fields:
+          type: array
+          description: 변경된 필드 (modified만 존재)
+          items:
+            $ref: "#/components/schemas/FieldChange"
+        interface:
+          $ref: "#/components/schemas/NodeInterface"
+    FieldChange:
+      type: object
+      properties:
+        field:
+          type: string
+          example: mtu
+        from:
+          type: string
+        to:
+          type: string
     ProviderCatalog:
       type: object
       properties:
@@ -230,9 +578,31 @@ const swaggerHTML = `<!doctype html>
   </body>
 </html>`
 
+const (
+	// resourceVersionHeader는 목록 응답 시점의 watch resourceVersion이다.
+	resourceVersionHeader = "X-Resource-Version"
+	// totalCountHeader는 페이지 적용 전 조건에 맞는 전체 레코드 수이다.
+	totalCountHeader = "X-Total-Count"
+	// continueHeader는 다음 페이지 토큰이다. 마지막 페이지이면 없다.
+	continueHeader = "X-Continue"
+	watchHeartbeat = 30 * time.Second
+	watchRetry     = 3 * time.Second
+)
+
 type Server struct {
 	addr  string
-	store *Store
+	store Backend
+	// elected는 leader로 선출되면 닫힌다. nil이면 항상 watch를 제공한다.
+	elected <-chan struct{}
+}
+
+// ServerOption은 Server 생성 옵션이다.
+type ServerOption func(*Server)
+
+// WithLeaderElection은 elected가 닫힌(leader) replica에서만 watch를 제공하게 한다.
+// 이벤트는 변경을 처리한 프로세스에서만 발행되므로 다른 replica의 watch는 아무것도 받지 못한다.
+func WithLeaderElection(elected <-chan struct{}) ServerOption {
+	return func(s *Server) { s.elected = elected }
 }
 
 type providerCatalogResponse struct {
@@ -254,8 +624,26 @@ type catalogNodeRecord struct {
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
-func NewServer(addr string, store *Store) *Server {
-	return &Server{addr: addr, store: store}
+func NewServer(addr string, store Backend, opts ...ServerOption) *Server {
+	s := &Server{addr: addr, store: store}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// watcher는 이 replica에서 watch를 제공할 수 있으면 Watcher를 반환한다.
+func (s *Server) watcher() (Watcher, bool) {
+	watcher, ok := s.store.(Watcher)
+	if !ok || s.elected == nil {
+		return watcher, ok
+	}
+	select {
+	case <-s.elected:
+		return watcher, true
+	default:
+		return nil, false
+	}
 }
 
 func (s *Server) Start(ctx context.Context) error {
@@ -266,12 +654,16 @@ func (s *Server) Start(ctx context.Context) error {
 	mux.HandleFunc("/v1/interfaces/providers", s.handleProviders)
 	mux.HandleFunc("/v1/interfaces/node-configs", s.handleList)
 	mux.HandleFunc("/v1/interfaces/node-configs/by-instance/", s.handleGetByInstance)
+	mux.HandleFunc("/v1/interfaces/node-configs/", s.handleNodeConfig)
+	mux.HandleFunc("/v1/interfaces/watch", s.handleWatch)
 
 	srv := &http.Server{
 		Addr:              s.addr,
 		Handler:           mux,
 		ReadHeaderTimeout: 5 * time.Second,
 		IdleTimeout:       60 * time.Second,
+		// 종료 시 watch 스트림 요청도 취소되도록 ctx를 요청 컨텍스트의 부모로 사용한다.
+		BaseContext: func(net.Listener) context.Context { return ctx },
 	}
 
 	go func() {
@@ -334,18 +726,122 @@ func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	providerID := r.URL.Query().Get("providerId")
-	if providerID == "" {
-		http.Error(w, "providerId required", http.StatusBadRequest)
+	query, err := ParseQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	records, err := s.store.List(r.Context(), providerID, "", "")
+	// 목록 조회 직전 resourceVersion부터 watch하면 누락 없이 이어받을 수 있다.
+	if watcher, ok := s.watcher(); ok {
+		w.Header().Set(resourceVersionHeader, watcher.ResourceVersion())
+	}
+	page, total, next, err := RunQuery(r.Context(), s.store, query)
+	if errors.Is(err, ErrInvalidContinue) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	writeJSON(w, records)
+	w.Header().Set(totalCountHeader, strconv.Itoa(total))
+	if next != "" {
+		w.Header().Set(continueHeader, next)
+	}
+	writeJSON(w, page)
+}
+
+// handleWatch는 inventory 변경을 SSE(text/event-stream)로 전달한다.
+// 이벤트 id는 resourceVersion이며, resourceVersion 쿼리 또는 Last-Event-ID 헤더로 이어받는다.
+func (s *Server) handleWatch(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.store.(Watcher); !ok {
+		http.Error(w, "inventory watch not available", http.StatusServiceUnavailable)
+		return
+	}
+	watcher, ok := s.watcher()
+	if !ok {
+		http.Error(w, "inventory watch is served only by the leader replica; retry against another replica", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	since := r.URL.Query().Get("resourceVersion")
+	if since == "" {
+		since = r.Header.Get("Last-Event-ID")
+	}
+	sub, err := watcher.Subscribe(r.URL.Query().Get("providerId"), since)
+	if errors.Is(err, ErrResourceVersionExpired) {
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	}
+	if errors.Is(err, ErrInvalidResourceVersion) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", watchRetry.Milliseconds()); err != nil {
+		return
+	}
+	// 처음 감시하는 경우 구독 시점 resourceVersion을 알려 이벤트가 없어도 재개 지점을 갖게 한다.
+	if since == "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n\n", sub.ResourceVersion); err != nil {
+			return
+		}
+	}
+	for _, ev := range sub.Backlog {
+		if writeEvent(w, ev) != nil {
+			return
+		}
+	}
+	if rc.Flush() != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(watchHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case ev, open := <-sub.Events():
+			if !open {
+				// 구독이 끊기면 스트림을 닫고 클라이언트가 마지막 id로 재연결하게 한다.
+				return
+			}
+			if writeEvent(w, ev) != nil {
+				return
+			}
+		}
+		if rc.Flush() != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w io.Writer, ev Event) error {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", ev.ResourceVersion, ev.Type, data)
+	return err
 }
 
 func (s *Server) handleGetByInstance(w http.ResponseWriter, r *http.Request) {
@@ -375,11 +871,144 @@ func (s *Server) handleGetByInstance(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, records)
 }
 
+// handleNodeConfig는 /v1/interfaces/node-configs/{nodeName}/{history|diff} 요청을 처리한다.
+func (s *Server) handleNodeConfig(w http.ResponseWriter, r *http.Request) {
+	if s.store == nil {
+		http.Error(w, "inventory store not available", http.StatusServiceUnavailable)
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	rest := strings.TrimPrefix(r.URL.Path, "/v1/interfaces/node-configs/")
+	nodeName, action, ok := strings.Cut(rest, "/")
+	if !ok || nodeName == "" {
+		http.NotFound(w, r)
+		return
+	}
+	var handler func(http.ResponseWriter, *http.Request, string, string)
+	switch action {
+	case "history":
+		handler = s.handleHistory
+	case "diff":
+		handler = s.handleDiff
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	providerID := r.URL.Query().Get("providerId")
+	if providerID == "" {
+		http.Error(w, "providerId required", http.StatusBadRequest)
+		return
+	}
+	handler(w, r, providerID, nodeName)
+}
+
+// handleHistory는 노드 이력을 최신순으로 반환한다.
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request, providerID, nodeName string) {
+	limit, err := positiveQueryInt(r, "limit")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	revisions, err := s.store.History(r.Context(), providerID, nodeName, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(revisions) == 0 {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, revisions)
+}
+
+// revisionDiff는 두 이력 사이의 인터페이스 변경 응답이다.
+type revisionDiff struct {
+	ProviderID   string               `json:"providerId"`
+	NodeName     string               `json:"nodeName"`
+	FromRevision int64                `json:"fromRevision"`
+	ToRevision   int64                `json:"toRevision"`
+	Summary      string               `json:"summary"`
+	Diff         viola.NodeConfigDiff `json:"diff"`
+}
+
+// handleDiff는 from→to 이력의 인터페이스 변경을 반환한다.
+// to를 생략하면 최신 이력, from을 생략하면 to 직전 이력을 사용한다.
+func (s *Server) handleDiff(w http.ResponseWriter, r *http.Request, providerID, nodeName string) {
+	from, err := positiveQueryInt(r, "from")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	to, err := positiveQueryInt(r, "to")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	revisions, err := s.store.History(r.Context(), providerID, nodeName, 0)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if len(revisions) == 0 {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	// revisions는 최신순이다.
+	toIdx := 0
+	if to > 0 {
+		toIdx = revisionIndex(revisions, int64(to))
+	}
+	fromIdx := toIdx + 1
+	if from > 0 {
+		fromIdx = revisionIndex(revisions, int64(from))
+	}
+	if toIdx < 0 || fromIdx < 0 {
+		http.Error(w, "revision not found (may have been pruned)", http.StatusNotFound)
+		return
+	}
+
+	resp := revisionDiff{ProviderID: providerID, NodeName: nodeName, ToRevision: revisions[toIdx].Revision}
+	var previous viola.NodeConfig
+	if fromIdx < len(revisions) {
+		previous = revisions[fromIdx].Config
+		resp.FromRevision = revisions[fromIdx].Revision
+	}
+	resp.Diff = viola.DiffNodeConfigs(previous, revisions[toIdx].Config)
+	resp.Summary = resp.Diff.String()
+	writeJSON(w, resp)
+}
+
+func revisionIndex(revisions []Revision, revision int64) int {
+	for i, rev := range revisions {
+		if rev.Revision == revision {
+			return i
+		}
+	}
+	return -1
+}
+
+// positiveQueryInt는 양의 정수 쿼리 값을 읽는다. 없으면 0이다.
+func positiveQueryInt(r *http.Request, name string) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return n, nil
+}
+
 func buildProviderCatalog(records []Record) providerCatalogResponse {
 	perProvider := make(map[string]*providerSummary)
 
 	for _, rec := range records {
-		if rec.ProviderID == "" {
+		if rec.ProviderID == "" || rec.RemovedAt != nil {
 			continue
 		}
 		entry, ok := perProvider[rec.ProviderID]
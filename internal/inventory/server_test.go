@@ -0,0 +1,190 @@
+package inventory
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"multinic-operator/pkg/viola"
+)
+
+func TestServer_NodeConfigDiff(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "inventory.json"))
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i, mtu := range []int{1500, 9000, 9000} {
+		node := viola.NodeConfig{NodeName: "worker-1", Interfaces: []viola.NodeInterface{
+			{PortID: "port-1", Name: "multinic0", MAC: "fa:16:3e:00:00:01", MTU: 1500},
+			{PortID: "port-2", Name: "multinic1", MAC: "fa:16:3e:00:00:02", MTU: mtu},
+		}}
+		if i == 2 {
+			node.Interfaces = node.Interfaces[1:]
+			node.Interfaces[0].Name = "multinic0"
+		}
+		if err := store.Upsert(ctx, "p", "", node, fmt.Sprintf("hash-%d", i), at.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+	}
+	srv := &Server{store: store}
+
+	get := func(path string) (*httptest.ResponseRecorder, revisionDiff) {
+		rec := httptest.NewRecorder()
+		srv.handleNodeConfig(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		var body revisionDiff
+		if rec.Code == http.StatusOK {
+			_ = json.Unmarshal(rec.Body.Bytes(), &body)
+		}
+		return rec, body
+	}
+
+	// 기본값은 최신 이력과 직전 이력 비교이다.
+	rec, body := get("/v1/interfaces/node-configs/worker-1/diff?providerId=p")
+	if rec.Code != http.StatusOK || body.FromRevision != 2 || body.ToRevision != 3 {
+		t.Fatalf("unexpected latest diff: %d %+v", rec.Code, body)
+	}
+	if body.Summary != "multinic0 name multinic1→multinic0; -multinic0" {
+		t.Fatalf("unexpected summary: %q", body.Summary)
+	}
+
+	rec, body = get("/v1/interfaces/node-configs/worker-1/diff?providerId=p&from=1&to=2")
+	if rec.Code != http.StatusOK || body.Summary != "multinic1 mtu 1500→9000" {
+		t.Fatalf("unexpected ranged diff: %d %+v", rec.Code, body)
+	}
+
+	// 첫 이력은 이전 이력이 없으므로 모든 인터페이스가 추가로 표시된다.
+	if _, body = get("/v1/interfaces/node-configs/worker-1/diff?providerId=p&to=1"); body.FromRevision != 0 || len(body.Diff.Interfaces) != 2 {
+		t.Fatalf("unexpected first revision diff: %+v", body)
+	}
+
+	for path, want := range map[string]int{
+		"/v1/interfaces/node-configs/worker-1/diff":                   http.StatusBadRequest,
+		"/v1/interfaces/node-configs/worker-1/diff?providerId=p&to=x": http.StatusBadRequest,
+		"/v1/interfaces/node-configs/worker-1/diff?providerId=p&to=9": http.StatusNotFound,
+		"/v1/interfaces/node-configs/worker-2/diff?providerId=p":      http.StatusNotFound,
+		"/v1/interfaces/node-configs/worker-1/unknown?providerId=p":   http.StatusNotFound,
+	} {
+		if rec, _ := get(path); rec.Code != want {
+			t.Fatalf("%s: expected %d, got %d", path, want, rec.Code)
+		}
+	}
+}
+
+func TestServer_WatchStream(t *testing.T) {
+	ctx := context.Background()
+	file, err := NewFileStore(filepath.Join(t.TempDir(), "inventory.json"))
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	store := NewNotifier(file, 2)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	upsert := func(providerID, nodeName string) {
+		t.Helper()
+		if err := store.Upsert(ctx, providerID, "", viola.NodeConfig{NodeName: nodeName}, nodeName, at); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+	}
+	upsert("p", "worker-1")
+	epoch, _, _ := strings.Cut(store.ResourceVersion(), ".")
+	upsert("other", "worker-9")
+
+	srv := httptest.NewServer(http.HandlerFunc((&Server{store: store}).handleWatch))
+	defer srv.Close()
+
+	// resourceVersion=1 이후 p의 이벤트만 재생되고, 이후 변경은 실시간으로 전달된다.
+	reqCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(reqCtx, http.MethodGet, srv.URL+"/v1/interfaces/watch?providerId=p", nil)
+	req.Header.Set("Last-Event-ID", epoch+".1")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	upsert("p", "worker-2")
+	if err := store.Delete(ctx, "p", "worker-1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	var got []string
+	for len(got) < 2 && scanner.Scan() {
+		line := scanner.Text()
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			var ev Event
+			if err := json.Unmarshal([]byte(data), &ev); err != nil {
+				t.Fatalf("decode event: %v", err)
+			}
+			got = append(got, fmt.Sprintf("%s %s %s", strings.TrimPrefix(ev.ResourceVersion, epoch+"."), ev.Type, ev.NodeName))
+		}
+	}
+	if fmt.Sprint(got) != "[3 upserted worker-2 4 deleted worker-1]" {
+		t.Fatalf("unexpected events: %v", got)
+	}
+
+	// 버퍼(2개)에서 이미 버린 resourceVersion이나 재시작 전(다른 epoch) 값으로는 재개할 수 없다.
+	for rv, want := range map[string]int{
+		epoch + ".1":  http.StatusGone,
+		epoch + ".99": http.StatusGone,
+		"1":           http.StatusGone,
+		epoch + ".x":  http.StatusBadRequest,
+	} {
+		rec := httptest.NewRecorder()
+		(&Server{store: store}).handleWatch(rec, httptest.NewRequest(http.MethodGet, "/v1/interfaces/watch?resourceVersion="+rv, nil))
+		if rec.Code != want {
+			t.Fatalf("resourceVersion %s: expected %d, got %d", rv, want, rec.Code)
+		}
+	}
+
+	// 재시작 후 순번이 이전 값을 넘어서도 epoch이 달라 그 사이 이벤트를 건너뛰지 않는다.
+	restarted := NewNotifier(file, 2)
+	for range 5 {
+		if err := restarted.Upsert(ctx, "p", "", viola.NodeConfig{NodeName: "worker-3"}, "x", at); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+	}
+	if _, err := restarted.Subscribe("p", epoch+".3"); !errors.Is(err, ErrResourceVersionExpired) {
+		t.Fatalf("expected resourceVersion from previous process to expire, got %v", err)
+	}
+}
+
+func TestServer_WatchRequiresLeader(t *testing.T) {
+	file, err := NewFileStore(filepath.Join(t.TempDir(), "inventory.json"))
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	elected := make(chan struct{})
+	srv := NewServer("", NewNotifier(file, 0), WithLeaderElection(elected))
+
+	// leader가 아니면 이벤트를 받을 수 없으므로 watch와 목록의 resourceVersion을 제공하지 않는다.
+	rec := httptest.NewRecorder()
+	srv.handleWatch(rec, httptest.NewRequest(http.MethodGet, "/v1/interfaces/watch", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 on non-leader, got %d", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	srv.handleList(rec, httptest.NewRequest(http.MethodGet, "/v1/interfaces/node-configs?providerId=p", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get(resourceVersionHeader) != "" {
+		t.Fatalf("unexpected non-leader list: %d %q", rec.Code, rec.Header().Get(resourceVersionHeader))
+	}
+
+	close(elected)
+	rec = httptest.NewRecorder()
+	srv.handleList(rec, httptest.NewRequest(http.MethodGet, "/v1/interfaces/node-configs?providerId=p", nil))
+	if rec.Header().Get(resourceVersionHeader) == "" {
+		t.Fatalf("expected resourceVersion header on leader")
+	}
+}
@@ -0,0 +1,499 @@
+package inventory
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	_ "github.com/jackc/pgx/v5/stdlib" // postgres driver ("pgx")
+	_ "modernc.org/sqlite"             // sqlite driver ("sqlite"), CGO 불필요
+
+	"multinic-operator/pkg/viola"
+)
+
+// sqlDialect는 SQL 저장소별 드라이버와 스키마 차이를 담는다.
+// 쿼리는 SQLite/PostgreSQL 모두 $n placeholder와 ON CONFLICT를 지원하므로 공통으로 사용한다.
+type sqlDialect struct {
+	name   string
+	driver string
+	// migrations[i]는 스키마 버전 i+1로 올리는 문장 목록이다. 한 번 배포된 항목은 수정하지 않는다.
+	migrations [][]string
+	// lock은 마이그레이션 트랜잭션 시작 시 실행해 여러 replica의 동시 마이그레이션을 막는다.
+	lock string
+	// collate는 문자열 정렬/비교를 Go와 같은 바이트 순서로 맞추는 절이다. (SQLite 기본값은 이미 바이트 순서)
+	collate string
+}
+
+var sqliteDialect = sqlDialect{
+	name:   BackendSQLite,
+	driver: "sqlite",
+	migrations: [][]string{
+		{
+			`CREATE TABLE IF NOT EXISTS inventory_records (
+				provider_id      TEXT NOT NULL,
+				node_name        TEXT NOT NULL,
+				instance_id      TEXT NOT NULL DEFAULT '',
+				owner            TEXT NOT NULL DEFAULT '',
+				config           TEXT NOT NULL,
+				last_config_hash TEXT NOT NULL,
+				updated_at       TIMESTAMP NOT NULL,
+				removed_at       TIMESTAMP,
+				PRIMARY KEY (provider_id, node_name)
+			)`,
+			`CREATE INDEX IF NOT EXISTS inventory_records_instance_id ON inventory_records (instance_id)`,
+		},
+		{
+			`CREATE TABLE IF NOT EXISTS inventory_revisions (
+				provider_id TEXT NOT NULL,
+				node_name   TEXT NOT NULL,
+				revision    INTEGER NOT NULL,
+				config_hash TEXT NOT NULL,
+				owner       TEXT NOT NULL DEFAULT '',
+				config      TEXT NOT NULL,
+				created_at  TIMESTAMP NOT NULL,
+				PRIMARY KEY (provider_id, node_name, revision)
+			)`,
+			`CREATE INDEX IF NOT EXISTS inventory_revisions_created_at ON inventory_revisions (created_at)`,
+			backfillRevisions,
+		},
+	},
+}
+
+var postgresDialect = sqlDialect{
+	name:   BackendPostgres,
+	driver: "pgx",
+	migrations: [][]string{
+		{
+			`CREATE TABLE IF NOT EXISTS inventory_records (
+				provider_id      TEXT NOT NULL,
+				node_name        TEXT NOT NULL,
+				instance_id      TEXT NOT NULL DEFAULT '',
+				owner            TEXT NOT NULL DEFAULT '',
+				config           JSONB NOT NULL,
+				last_config_hash TEXT NOT NULL,
+				updated_at       TIMESTAMPTZ NOT NULL,
+				removed_at       TIMESTAMPTZ,
+				PRIMARY KEY (provider_id, node_name)
+			)`,
+			`CREATE INDEX IF NOT EXISTS inventory_records_instance_id ON inventory_records (instance_id)`,
+		},
+		{
+			`CREATE TABLE IF NOT EXISTS inventory_revisions (
+				provider_id TEXT NOT NULL,
+				node_name   TEXT NOT NULL,
+				revision    BIGINT NOT NULL,
+				config_hash TEXT NOT NULL,
+				owner       TEXT NOT NULL DEFAULT '',
+				config      JSONB NOT NULL,
+				created_at  TIMESTAMPTZ NOT NULL,
+				PRIMARY KEY (provider_id, node_name, revision)
+			)`,
+			`CREATE INDEX IF NOT EXISTS inventory_revisions_created_at ON inventory_revisions (created_at)`,
+			backfillRevisions,
+		},
+	},
+	lock:    `SELECT pg_advisory_xact_lock(7142032)`,
+	collate: ` COLLATE "C"`,
+}
+
+// backfillRevisions는 이력 테이블 도입 시 현재 레코드를 첫 이력으로 채운다.
+const backfillRevisions = `INSERT INTO inventory_revisions (provider_id, node_name, revision, config_hash, owner, config, created_at)
+	SELECT provider_id, node_name, 1, last_config_hash, owner, config, updated_at FROM inventory_records`
+
+// SQLStore는 SQLite/PostgreSQL 기반 Backend이다.
+// 레코드 단위로 갱신하므로 대규모 환경과 (PostgreSQL 사용 시) 여러 replica에서 사용할 수 있다.
+type SQLStore struct {
+	db        *sql.DB
+	dialect   sqlDialect
+	retention HistoryRetention
+}
+
+// NewSQLiteStore는 path의 SQLite DB를 연다. (WAL 모드)
+func NewSQLiteStore(path string, opts ...Option) (*SQLStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	dsn := "file:" + path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	db, err := sql.Open(sqliteDialect.driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite는 쓰기 잠금이 DB 단위이므로 연결을 하나로 제한해 SQLITE_BUSY를 피한다.
+	db.SetMaxOpenConns(1)
+	return &SQLStore{db: db, dialect: sqliteDialect, retention: buildOptions(opts).retention}, nil
+}
+
+// NewPostgresStore는 dsn(postgres://...)으로 PostgreSQL에 연결한다.
+func NewPostgresStore(dsn string, opts ...Option) (*SQLStore, error) {
+	db, err := sql.Open(postgresDialect.driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLStore{db: db, dialect: postgresDialect, retention: buildOptions(opts).retention}, nil
+}
+
+// Init은 연결을 확인하고 스키마 마이그레이션을 적용한다.
+func (s *SQLStore) Init(ctx context.Context) error {
+	if err := s.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("connect %s inventory: %w", s.dialect.name, err)
+	}
+	return s.migrate(ctx)
+}
+
+// SchemaVersion은 적용된 마지막 스키마 버전을 반환한다.
+func (s *SQLStore) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM inventory_schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// migrate는 적용되지 않은 마이그레이션을 버전 순서대로 트랜잭션 안에서 실행한다.
+func (s *SQLStore) migrate(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+	if s.dialect.lock != "" {
+		if _, err := tx.ExecContext(ctx, s.dialect.lock); err != nil {
+			return fmt.Errorf("lock inventory schema: %w", err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS inventory_schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		return err
+	}
+	var current int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM inventory_schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+	for i := current; i < len(s.dialect.migrations); i++ {
+		for _, stmt := range s.dialect.migrations[i] {
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("inventory migration %d: %w", i+1, err)
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO inventory_schema_migrations (version, applied_at) VALUES ($1, $2)`, i+1, time.Now().UTC()); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetHash는 providerID+nodeName 기준으로 마지막 해시를 반환한다.
+// 삭제 표시된 레코드는 재전송되도록 빈 값을 반환한다.
+func (s *SQLStore) GetHash(ctx context.Context, providerID, nodeName string) (string, error) {
+	var hash string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT last_config_hash FROM inventory_records WHERE provider_id = $1 AND node_name = $2 AND removed_at IS NULL`,
+		providerID, nodeName,
+	).Scan(&hash)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return hash, err
+}
+
+// Upsert는 최신 NodeConfig를 저장하고, 해시가 바뀌었으면 이력을 추가한 뒤 보존 한도를 적용한다.
+func (s *SQLStore) Upsert(ctx context.Context, providerID, owner string, node viola.NodeConfig, hash string, updatedAt time.Time) error {
+	config, err := json.Marshal(node)
+	if err != nil {
+		return err
+	}
+	at := updatedAt.UTC()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO inventory_records
+		(provider_id, node_name, instance_id, owner, config, last_config_hash, updated_at, removed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULL)
+		ON CONFLICT (provider_id, node_name) DO UPDATE SET
+			instance_id = excluded.instance_id,
+			owner = excluded.owner,
+			config = excluded.config,
+			last_config_hash = excluded.last_config_hash,
+			updated_at = excluded.updated_at,
+			removed_at = NULL`,
+		providerID, node.NodeName, node.InstanceID, owner, string(config), hash, at,
+	)
+	if err != nil {
+		return err
+	}
+
+	var (
+		latest     int64
+		latestHash string
+	)
+	err = tx.QueryRowContext(ctx,
+		`SELECT revision, config_hash FROM inventory_revisions WHERE provider_id = $1 AND node_name = $2 ORDER BY revision DESC LIMIT 1`,
+		providerID, node.NodeName,
+	).Scan(&latest, &latestHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == sql.ErrNoRows || latestHash != hash {
+		latest++
+		if _, err := tx.ExecContext(ctx, `INSERT INTO inventory_revisions
+			(provider_id, node_name, revision, config_hash, owner, config, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (provider_id, node_name, revision) DO NOTHING`,
+			providerID, node.NodeName, latest, hash, owner, string(config), at,
+		); err != nil {
+			return err
+		}
+	}
+	if err := s.pruneHistory(ctx, tx, providerID, node.NodeName, latest, at); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// pruneHistory는 upsert한 노드의 개수/기간 한도를 넘는 이력을 제거한다.
+// 최신 이력(latest)은 현재 상태이므로 유지한다. 다른 노드는 PruneHistory가 주기적으로 정리한다.
+func (s *SQLStore) pruneHistory(ctx context.Context, tx *sql.Tx, providerID, nodeName string, latest int64, now time.Time) error {
+	if n := s.retention.MaxRevisions; n > 0 {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM inventory_revisions WHERE provider_id = $1 AND node_name = $2 AND revision <= $3`,
+			providerID, nodeName, latest-int64(n),
+		); err != nil {
+			return err
+		}
+	}
+	if s.retention.MaxAge > 0 {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM inventory_revisions WHERE provider_id = $1 AND node_name = $2 AND revision < $3 AND created_at < $4`,
+			providerID, nodeName, latest, now.Add(-s.retention.MaxAge),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PruneHistory는 모든 노드에서 보존 기간이 지난 이력을 제거한다.
+// 레코드가 남아 있는 노드의 최신 이력은 현재 상태이므로 유지한다.
+func (s *SQLStore) PruneHistory(ctx context.Context, now time.Time) error {
+	if s.retention.MaxAge <= 0 {
+		return nil
+	}
+	_, err := s.db.ExecContext(ctx, `DELETE FROM inventory_revisions WHERE created_at < $1 AND (
+		NOT EXISTS (SELECT 1 FROM inventory_records r
+			WHERE r.provider_id = inventory_revisions.provider_id AND r.node_name = inventory_revisions.node_name)
+		OR revision < (SELECT MAX(m.revision) FROM inventory_revisions m
+			WHERE m.provider_id = inventory_revisions.provider_id AND m.node_name = inventory_revisions.node_name))`,
+		now.Add(-s.retention.MaxAge),
+	)
+	return err
+}
+
+// History는 providerID+nodeName의 이력을 최신순으로 반환한다.
+func (s *SQLStore) History(ctx context.Context, providerID, nodeName string, limit int) ([]Revision, error) {
+	query := `SELECT revision, config_hash, owner, config, created_at FROM inventory_revisions
+		WHERE provider_id = $1 AND node_name = $2 ORDER BY revision DESC`
+	args := []any{providerID, nodeName}
+	if limit > 0 {
+		query += ` LIMIT $3`
+		args = append(args, limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]Revision, 0)
+	for rows.Next() {
+		rev := Revision{ProviderID: providerID, NodeName: nodeName}
+		var config []byte
+		if err := rows.Scan(&rev.Revision, &rev.ConfigHash, &rev.Owner, &config, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(config, &rev.Config); err != nil {
+			return nil, fmt.Errorf("decode inventory revision %s/%s#%d: %w", providerID, nodeName, rev.Revision, err)
+		}
+		rev.CreatedAt = rev.CreatedAt.UTC()
+		out = append(out, rev)
+	}
+	return out, rows.Err()
+}
+
+// MarkRemoved는 Viola에서 삭제된 노드 레코드에 삭제 시각을 기록한다.
+func (s *SQLStore) MarkRemoved(ctx context.Context, providerID, nodeName string, removedAt time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE inventory_records SET removed_at = $3 WHERE provider_id = $1 AND node_name = $2 AND removed_at IS NULL`,
+		providerID, nodeName, removedAt.UTC(),
+	)
+	return err
+}
+
+// Delete는 providerID+nodeName 레코드를 제거한다.
+func (s *SQLStore) Delete(ctx context.Context, providerID, nodeName string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM inventory_records WHERE provider_id = $1 AND node_name = $2`,
+		providerID, nodeName,
+	)
+	return err
+}
+
+// List는 조건(providerID/nodeName/instanceID)으로 레코드를 조회한다.
+func (s *SQLStore) List(ctx context.Context, providerID, nodeName, instanceID string) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT provider_id, node_name, instance_id, owner, config, last_config_hash, updated_at, removed_at
+		FROM inventory_records
+		WHERE ($1 = '' OR provider_id = $1) AND ($2 = '' OR node_name = $2) AND ($3 = '' OR instance_id = $3)
+		ORDER BY provider_id, node_name`,
+		providerID, nodeName, instanceID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecords(rows)
+}
+
+// Query는 q를 SQL로 평가한다. 레코드 컬럼 조건, 정렬, continue(keyset), limit은 SQL에서 처리하고,
+// 인터페이스 조건이 있으면 컬럼 조건으로 거른 결과를 메모리에서 마저 평가한다.
+func (s *SQLStore) Query(ctx context.Context, q Query) ([]Record, int, string, error) {
+	var (
+		where []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if q.ProviderID != "" {
+		where = append(where, "provider_id = "+arg(q.ProviderID))
+	}
+	if q.NodeName != "" {
+		where = append(where, "node_name = "+arg(q.NodeName))
+	}
+	if q.NodeNamePrefix != "" {
+		// LIKE는 SQLite에서 대소문자를 구분하지 않으므로 substr로 비교한다.
+		where = append(where, fmt.Sprintf("substr(node_name, 1, %s) = %s", arg(utf8.RuneCountInString(q.NodeNamePrefix)), arg(q.NodeNamePrefix)))
+	}
+	if q.InstanceID != "" {
+		where = append(where, "instance_id = "+arg(q.InstanceID))
+	}
+	if !q.UpdatedAfter.IsZero() {
+		where = append(where, "updated_at > "+arg(q.UpdatedAfter.UTC()))
+	}
+	if !q.IncludeRemoved {
+		where = append(where, "removed_at IS NULL")
+	}
+
+	if q.hasInterfaceFilter() {
+		records, err := s.selectRecords(ctx, where, args, "")
+		if err != nil {
+			return nil, 0, "", err
+		}
+		return q.Apply(records)
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM inventory_records"+whereClause(where), args...).Scan(&total); err != nil {
+		return nil, 0, "", err
+	}
+
+	columns := []string{"node_name" + s.dialect.collate, "provider_id" + s.dialect.collate}
+	switch q.Sort {
+	case SortInstanceID:
+		columns = append([]string{"instance_id" + s.dialect.collate}, columns...)
+	case SortUpdatedAt:
+		columns = append([]string{"updated_at"}, columns...)
+	}
+	dir, op := "ASC", ">"
+	if q.Descending {
+		dir, op = "DESC", "<"
+	}
+	if q.Continue != "" {
+		after, err := q.decodeContinue()
+		if err != nil {
+			return nil, 0, "", err
+		}
+		// 마지막으로 반환한 레코드의 정렬 키 다음부터 이어간다. (compare와 같은 순서)
+		keys := []string{arg(after.NodeName), arg(after.ProviderID)}
+		switch q.Sort {
+		case SortInstanceID:
+			keys = append([]string{arg(after.InstanceID)}, keys...)
+		case SortUpdatedAt:
+			keys = append([]string{arg(after.UpdatedAt.UTC())}, keys...)
+		}
+		where = append(where, fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "), op, strings.Join(keys, ", ")))
+	}
+	order := make([]string, len(columns))
+	for i, col := range columns {
+		order[i] = col + " " + dir
+	}
+	tail := " ORDER BY " + strings.Join(order, ", ")
+	if q.Limit > 0 {
+		// 한 건 더 읽어 다음 페이지가 있는지 확인한다.
+		tail += " LIMIT " + arg(q.Limit+1)
+	}
+	page, err := s.selectRecords(ctx, where, args, tail)
+	if err != nil {
+		return nil, 0, "", err
+	}
+	var next string
+	if q.Limit > 0 && len(page) > q.Limit {
+		page = page[:q.Limit]
+		next = q.encodeContinue(page[len(page)-1])
+	}
+	return page, total, next, nil
+}
+
+func (s *SQLStore) selectRecords(ctx context.Context, where []string, args []any, tail string) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT provider_id, node_name, instance_id, owner, config, last_config_hash, updated_at, removed_at
+		FROM inventory_records`+whereClause(where)+tail, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanRecords(rows)
+}
+
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+func scanRecords(rows *sql.Rows) ([]Record, error) {
+	defer rows.Close()
+
+	out := make([]Record, 0)
+	for rows.Next() {
+		var (
+			rec       Record
+			config    []byte
+			removedAt sql.NullTime
+		)
+		if err := rows.Scan(&rec.ProviderID, &rec.NodeName, &rec.InstanceID, &rec.Owner, &config, &rec.LastConfigHash, &rec.UpdatedAt, &removedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(config, &rec.Config); err != nil {
+			return nil, fmt.Errorf("decode inventory record %s/%s: %w", rec.ProviderID, rec.NodeName, err)
+		}
+		rec.UpdatedAt = rec.UpdatedAt.UTC()
+		if removedAt.Valid {
+			at := removedAt.Time.UTC()
+			rec.RemovedAt = &at
+		}
+		out = append(out, rec)
+	}
+	return out, rows.Err()
+}
+
+// Close는 DB 연결을 닫는다.
+func (s *SQLStore) Close() error {
+	return s.db.Close()
+}
@@ -5,39 +5,39 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
 	"multinic-operator/pkg/viola"
 )
 
-type Store struct {
-	path string
-	mu   sync.Mutex
-	data map[string]Record
-}
-
-type Record struct {
-	ProviderID     string           `json:"providerId"`
-	NodeName       string           `json:"nodeName"`
-	InstanceID     string           `json:"instanceId"`
-	Config         viola.NodeConfig `json:"config"`
-	LastConfigHash string           `json:"lastConfigHash"`
-	UpdatedAt      time.Time        `json:"updatedAt"`
+// FileStore는 JSON 파일 하나에 전체 레코드를 저장하는 Backend이다.
+// 변경마다 파일 전체를 다시 쓰므로 단일 replica/소규모 환경용이다.
+type FileStore struct {
+	path      string
+	retention HistoryRetention
+	mu        sync.Mutex
+	data      map[string]Record
+	// history는 노드별 이력이며 revision 오름차순이다.
+	history map[string][]Revision
 }
 
 type fileData struct {
-	Records []Record `json:"records"`
+	Records []Record   `json:"records"`
+	History []Revision `json:"history,omitempty"`
 }
 
-// NewStore는 파일 기반 인벤토리 저장소를 초기화한다.
-func NewStore(path string) (*Store, error) {
+// NewFileStore는 파일 기반 인벤토리 저장소를 초기화한다.
+func NewFileStore(path string, opts ...Option) (*FileStore, error) {
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return nil, err
 	}
-	store := &Store{
-		path: path,
-		data: make(map[string]Record),
+	store := &FileStore{
+		path:      path,
+		retention: buildOptions(opts).retention,
+		data:      make(map[string]Record),
+		history:   make(map[string][]Revision),
 	}
 	if err := store.load(); err != nil {
 		return nil, err
@@ -46,38 +46,137 @@ func NewStore(path string) (*Store, error) {
 }
 
 // Init은 파일 기반 저장소에서 별도 초기화가 필요 없음을 나타낸다.
-func (s *Store) Init(_ context.Context) error {
-	// No-op for file-based store; load is done in NewStore.
+func (s *FileStore) Init(_ context.Context) error {
+	// No-op for file-based store; load is done in NewFileStore.
 	return nil
 }
 
 // GetHash는 providerID+nodeName 기준으로 마지막 해시를 반환한다.
-func (s *Store) GetHash(_ context.Context, providerID, nodeName string) (string, error) {
+// 삭제 표시된 레코드는 재전송되도록 빈 값을 반환한다.
+func (s *FileStore) GetHash(_ context.Context, providerID, nodeName string) (string, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if rec, ok := s.data[key(providerID, nodeName)]; ok {
+	if rec, ok := s.data[key(providerID, nodeName)]; ok && rec.RemovedAt == nil {
 		return rec.LastConfigHash, nil
 	}
 	return "", nil
 }
 
 // Upsert는 최신 NodeConfig를 저장하고 파일에 반영한다.
-func (s *Store) Upsert(_ context.Context, providerID string, node viola.NodeConfig, hash string, updatedAt time.Time) error {
+// owner는 전송 주체 OpenstackConfig(namespace/name)이며 삭제 시 정리 대상을 찾는 데 사용한다.
+func (s *FileStore) Upsert(_ context.Context, providerID, owner string, node viola.NodeConfig, hash string, updatedAt time.Time) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.data[key(providerID, node.NodeName)] = Record{
+	k := key(providerID, node.NodeName)
+	s.data[k] = Record{
 		ProviderID:     providerID,
 		NodeName:       node.NodeName,
 		InstanceID:     node.InstanceID,
+		Owner:          owner,
 		Config:         node,
 		LastConfigHash: hash,
 		UpdatedAt:      updatedAt.UTC(),
 	}
+	revs := s.history[k]
+	if len(revs) == 0 || revs[len(revs)-1].ConfigHash != hash {
+		next := int64(1)
+		if len(revs) > 0 {
+			next = revs[len(revs)-1].Revision + 1
+		}
+		s.history[k] = append(revs, Revision{
+			ProviderID: providerID,
+			NodeName:   node.NodeName,
+			Revision:   next,
+			ConfigHash: hash,
+			Owner:      owner,
+			Config:     node,
+			CreatedAt:  updatedAt.UTC(),
+		})
+	}
+	s.pruneHistory(k, updatedAt.UTC())
+	return s.persist()
+}
+
+// History는 providerID+nodeName의 이력을 최신순으로 반환한다.
+func (s *FileStore) History(_ context.Context, providerID, nodeName string, limit int) ([]Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	revs := s.history[key(providerID, nodeName)]
+	out := make([]Revision, 0, len(revs))
+	for i := len(revs) - 1; i >= 0; i-- {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+		out = append(out, revs[i])
+	}
+	return out, nil
+}
+
+// PruneHistory는 모든 노드에서 보존 기간이 지난 이력을 제거한다.
+func (s *FileStore) PruneHistory(_ context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k := range s.history {
+		s.pruneHistory(k, now.UTC())
+	}
+	return s.persist()
+}
+
+// pruneHistory는 노드 k의 보존 한도(개수/기간)를 넘는 이력을 제거한다.
+// 레코드가 남아 있는 노드의 최신 이력은 현재 상태이므로 유지한다.
+func (s *FileStore) pruneHistory(k string, now time.Time) {
+	revs := s.history[k]
+	_, live := s.data[k]
+	if n := s.retention.MaxRevisions; n > 0 && len(revs) > n {
+		revs = revs[len(revs)-n:]
+	}
+	if s.retention.MaxAge > 0 {
+		cutoff := now.Add(-s.retention.MaxAge)
+		kept := revs[:0:0]
+		for i, rev := range revs {
+			if rev.CreatedAt.Before(cutoff) && (!live || i < len(revs)-1) {
+				continue
+			}
+			kept = append(kept, rev)
+		}
+		revs = kept
+	}
+	if len(revs) == 0 {
+		delete(s.history, k)
+		return
+	}
+	s.history[k] = revs
+}
+
+// MarkRemoved는 Viola에서 삭제된 노드 레코드에 삭제 시각을 기록한다.
+func (s *FileStore) MarkRemoved(_ context.Context, providerID, nodeName string, removedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := key(providerID, nodeName)
+	rec, ok := s.data[k]
+	if !ok || rec.RemovedAt != nil {
+		return nil
+	}
+	at := removedAt.UTC()
+	rec.RemovedAt = &at
+	s.data[k] = rec
+	return s.persist()
+}
+
+// Delete는 providerID+nodeName 레코드를 제거하고 파일에 반영한다.
+func (s *FileStore) Delete(_ context.Context, providerID, nodeName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := key(providerID, nodeName)
+	if _, ok := s.data[k]; !ok {
+		return nil
+	}
+	delete(s.data, k)
 	return s.persist()
 }
 
 // List는 조건(providerID/nodeName/instanceID)으로 레코드를 조회한다.
-func (s *Store) List(_ context.Context, providerID, nodeName, instanceID string) ([]Record, error) {
+func (s *FileStore) List(_ context.Context, providerID, nodeName, instanceID string) ([]Record, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -94,10 +193,17 @@ func (s *Store) List(_ context.Context, providerID, nodeName, instanceID string)
 		}
 		out = append(out, rec)
 	}
+	// SQL 저장소와 같은 순서로 반환한다.
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].ProviderID != out[j].ProviderID {
+			return out[i].ProviderID < out[j].ProviderID
+		}
+		return out[i].NodeName < out[j].NodeName
+	})
 	return out, nil
 }
 
-func (s *Store) load() error {
+func (s *FileStore) load() error {
 	raw, err := os.ReadFile(s.path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -112,14 +218,24 @@ func (s *Store) load() error {
 	for _, rec := range payload.Records {
 		s.data[key(rec.ProviderID, rec.NodeName)] = rec
 	}
+	for _, rev := range payload.History {
+		k := key(rev.ProviderID, rev.NodeName)
+		s.history[k] = append(s.history[k], rev)
+	}
+	for _, revs := range s.history {
+		sort.Slice(revs, func(i, j int) bool { return revs[i].Revision < revs[j].Revision })
+	}
 	return nil
 }
 
-func (s *Store) persist() error {
+func (s *FileStore) persist() error {
 	payload := fileData{Records: make([]Record, 0, len(s.data))}
 	for _, rec := range s.data {
 		payload.Records = append(payload.Records, rec)
 	}
+	for _, revs := range s.history {
+		payload.History = append(payload.History, revs...)
+	}
 	raw, err := json.MarshalIndent(payload, "", "  ")
 	if err != nil {
 		return err
@@ -131,6 +247,11 @@ func (s *Store) persist() error {
 	return os.Rename(tmp, s.path)
 }
 
+// Close는 파일 기반 저장소에서 정리할 자원이 없음을 나타낸다.
+func (s *FileStore) Close() error {
+	return nil
+}
+
 func key(providerID, nodeName string) string {
 	return providerID + "|" + nodeName
 }
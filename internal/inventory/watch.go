@@ -0,0 +1,254 @@
+package inventory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"multinic-operator/pkg/viola"
+)
+
+// Watch 이벤트 종류
+const (
+	EventUpserted = "upserted"
+	EventRemoved  = "removed"
+	EventDeleted  = "deleted"
+)
+
+// 감시 기본값.
+const (
+	// DefaultWatchBuffer는 재연결(resourceVersion 재개)을 위해 보관하는 최근 이벤트 수이다.
+	DefaultWatchBuffer = 1024
+	// subscriberQueue는 구독자별 미전송 이벤트 한도이다. 넘으면 구독을 끊고 클라이언트가 재개하게 한다.
+	subscriberQueue = 256
+)
+
+var (
+	// ErrResourceVersionExpired는 요청한 resourceVersion 이후 이벤트가 이미 버려졌거나
+	// 다른 프로세스(재시작 전)에서 발급되어 재개할 수 없을 때 반환된다.
+	// 클라이언트는 목록을 다시 조회한 뒤 새로 감시해야 한다.
+	ErrResourceVersionExpired = errors.New("resourceVersion is too old or unknown; relist and watch again")
+	// ErrInvalidResourceVersion은 resourceVersion 형식이 잘못되었을 때 반환된다.
+	ErrInvalidResourceVersion = errors.New("resourceVersion must be <epoch>.<n> as returned by the server")
+)
+
+// Event는 inventory 레코드 변경 알림이다.
+// ResourceVersion은 "<epoch>.<n>" 형식이다. epoch은 프로세스마다 새로 정해지므로
+// 재시작 전 값으로는 재개할 수 없다. (n은 프로세스 내에서 단조 증가)
+type Event struct {
+	Type            string  `json:"type"`
+	ResourceVersion string  `json:"resourceVersion"`
+	ProviderID      string  `json:"providerId"`
+	NodeName        string  `json:"nodeName"`
+	Record          *Record `json:"record,omitempty"`
+
+	seq uint64
+}
+
+// Watcher는 inventory 변경을 구독할 수 있는 저장소이다.
+type Watcher interface {
+	// ResourceVersion은 마지막 이벤트의 resourceVersion이다.
+	ResourceVersion() string
+	// Subscribe는 providerID(빈 값은 전체)의 since 이후 이벤트를 구독한다. (since 빈 값은 지금부터)
+	Subscribe(providerID string, since string) (*Subscription, error)
+}
+
+// Notifier는 Backend 변경을 구독자에게 전달하는 래퍼이다.
+// 이벤트는 이 프로세스에서 일어난 변경만 포함한다.
+type Notifier struct {
+	Backend
+
+	// epoch은 이 프로세스의 resourceVersion 접두사이다.
+	epoch  string
+	mu     sync.Mutex
+	rv     uint64
+	events []Event
+	// dropped는 버퍼에서 버린 마지막 이벤트의 resourceVersion이다.
+	dropped uint64
+	size    int
+	subs    map[*Subscription]struct{}
+}
+
+var (
+	_ Backend = (*Notifier)(nil)
+	_ Watcher = (*Notifier)(nil)
+	_ Querier = (*Notifier)(nil)
+)
+
+// NewNotifier는 backend를 감싸 최근 buffer개 이벤트를 보관하는 Notifier를 만든다. (0 이하이면 기본값)
+func NewNotifier(backend Backend, buffer int) *Notifier {
+	if buffer <= 0 {
+		buffer = DefaultWatchBuffer
+	}
+	return &Notifier{Backend: backend, epoch: newEpoch(), size: buffer, subs: make(map[*Subscription]struct{})}
+}
+
+func newEpoch() string {
+	var b [6]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// Upsert는 저장 후 upserted 이벤트를 발행한다.
+func (n *Notifier) Upsert(ctx context.Context, providerID, owner string, node viola.NodeConfig, hash string, updatedAt time.Time) error {
+	if err := n.Backend.Upsert(ctx, providerID, owner, node, hash, updatedAt); err != nil {
+		return err
+	}
+	n.publish(Event{Type: EventUpserted, ProviderID: providerID, NodeName: node.NodeName, Record: &Record{
+		ProviderID:     providerID,
+		NodeName:       node.NodeName,
+		InstanceID:     node.InstanceID,
+		Owner:          owner,
+		Config:         node,
+		LastConfigHash: hash,
+		UpdatedAt:      updatedAt.UTC(),
+	}})
+	return nil
+}
+
+// MarkRemoved는 삭제 표시 후 removed 이벤트를 발행한다.
+func (n *Notifier) MarkRemoved(ctx context.Context, providerID, nodeName string, removedAt time.Time) error {
+	if err := n.Backend.MarkRemoved(ctx, providerID, nodeName, removedAt); err != nil {
+		return err
+	}
+	ev := Event{Type: EventRemoved, ProviderID: providerID, NodeName: nodeName}
+	if records, err := n.List(ctx, providerID, nodeName, ""); err == nil && len(records) == 1 {
+		ev.Record = &records[0]
+	}
+	n.publish(ev)
+	return nil
+}
+
+// Delete는 레코드 제거 후 deleted 이벤트를 발행한다.
+func (n *Notifier) Delete(ctx context.Context, providerID, nodeName string) error {
+	if err := n.Backend.Delete(ctx, providerID, nodeName); err != nil {
+		return err
+	}
+	n.publish(Event{Type: EventDeleted, ProviderID: providerID, NodeName: nodeName})
+	return nil
+}
+
+// Query는 감싼 Backend로 q를 평가한다.
+func (n *Notifier) Query(ctx context.Context, q Query) ([]Record, int, string, error) {
+	return RunQuery(ctx, n.Backend, q)
+}
+
+// ResourceVersion은 마지막 이벤트의 resourceVersion이다.
+func (n *Notifier) ResourceVersion() string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.formatRV(n.rv)
+}
+
+// Subscribe는 providerID의 since 이후 이벤트를 구독한다.
+// since의 epoch이 이 프로세스와 다르거나(재시작 전 값) 버퍼보다 오래되었으면 ErrResourceVersionExpired를 반환한다.
+func (n *Notifier) Subscribe(providerID string, since string) (*Subscription, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	resume := since != ""
+	var seq uint64
+	if resume {
+		var err error
+		if seq, err = n.parseRV(since); err != nil {
+			return nil, err
+		}
+		if seq > n.rv || seq < n.dropped {
+			return nil, ErrResourceVersionExpired
+		}
+	}
+	sub := &Subscription{ResourceVersion: n.formatRV(n.rv), providerID: providerID, ch: make(chan Event, subscriberQueue), owner: n}
+	if resume {
+		for _, ev := range n.events {
+			if ev.seq > seq && sub.matches(ev) {
+				sub.Backlog = append(sub.Backlog, ev)
+			}
+		}
+	}
+	n.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+func (n *Notifier) publish(ev Event) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.rv++
+	ev.seq = n.rv
+	ev.ResourceVersion = n.formatRV(n.rv)
+	n.events = append(n.events, ev)
+	if over := len(n.events) - n.size; over > 0 {
+		n.dropped = n.events[over-1].seq
+		n.events = append(n.events[:0:0], n.events[over:]...)
+	}
+	for sub := range n.subs {
+		if !sub.matches(ev) {
+			continue
+		}
+		select {
+		case sub.ch <- ev:
+		default:
+			// 느린 구독자는 끊는다. 클라이언트는 마지막 id로 재개한다.
+			delete(n.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+func (n *Notifier) formatRV(seq uint64) string {
+	return fmt.Sprintf("%s.%d", n.epoch, seq)
+}
+
+// parseRV는 이 프로세스에서 발급한 resourceVersion의 순번을 반환한다.
+// epoch이 없거나 다르면(재시작 전 값 포함) ErrResourceVersionExpired이다.
+func (n *Notifier) parseRV(rv string) (uint64, error) {
+	epoch, raw, ok := strings.Cut(rv, ".")
+	if !ok || epoch != n.epoch {
+		return 0, ErrResourceVersionExpired
+	}
+	seq, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidResourceVersion
+	}
+	return seq, nil
+}
+
+func (n *Notifier) unsubscribe(sub *Subscription) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.subs[sub]; ok {
+		delete(n.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscription은 구독 한 건이다. Backlog를 먼저 전달한 뒤 Events를 읽는다.
+type Subscription struct {
+	// ResourceVersion은 구독 시점의 resourceVersion이다. 이후 이벤트는 Events로 전달된다.
+	ResourceVersion string
+	// Backlog는 구독 시점에 버퍼에서 재생할 이벤트이다.
+	Backlog    []Event
+	providerID string
+	ch         chan Event
+	owner      *Notifier
+}
+
+// Events는 새 이벤트 채널이다. 구독이 끊기면 닫힌다.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Close는 구독을 해제한다.
+func (s *Subscription) Close() {
+	s.owner.unsubscribe(s)
+}
+
+func (s *Subscription) matches(ev Event) bool {
+	return s.providerID == "" || s.providerID == ev.ProviderID
+}
@@ -0,0 +1,430 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var openstackconfiglog = logf.Log.WithName("openstackconfig-resource")
+
+// uuidPattern은 Nova/Neutron 리소스 ID(하이픈 포함 UUID) 형식이다.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// endpointInterfaces는 Keystone 카탈로그 endpoint interface 허용값이다.
+var endpointInterfaces = []string{"public", "internal", "admin"}
+
+// SetupOpenstackConfigWebhookWithManager registers the webhook for OpenstackConfig in the manager.
+// encrypt key Secret은 캐시 지연 없이 확인하도록 APIReader로 조회한다.
+func SetupOpenstackConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&multinicv1alpha1.OpenstackConfig{}).
+		WithValidator(&OpenstackConfigCustomValidator{Reader: mgr.GetAPIReader()}).
+		WithDefaulter(&OpenstackConfigCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-multinic-example-com-v1alpha1-openstackconfig,mutating=true,failurePolicy=fail,sideEffects=None,groups=multinic.example.com,resources=openstackconfigs,verbs=create;update,versions=v1alpha1,name=mopenstackconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// OpenstackConfigCustomDefaulter는 spec의 ID 목록과 포트 상태 값을 정규화한다.
+// settings 기본값은 OpenstackOperatorConfig가 가려지지 않도록 CR에 기록하지 않고 reconcile 시점에 적용한다.
+type OpenstackConfigCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &OpenstackConfigCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind OpenstackConfig.
+func (d *OpenstackConfigCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	cfg, ok := obj.(*multinicv1alpha1.OpenstackConfig)
+	if !ok {
+		return fmt.Errorf("expected an OpenstackConfig object but got %T", obj)
+	}
+	openstackconfiglog.V(1).Info("defaulting for OpenstackConfig", "name", cfg.GetName())
+
+	cfg.Spec.VmNames = normalizeList(cfg.Spec.VmNames, false)
+	cfg.Spec.SubnetIDs = normalizeList(cfg.Spec.SubnetIDs, false)
+	cfg.Spec.OperatorConfigName = strings.TrimSpace(cfg.Spec.OperatorConfigName)
+	if s := cfg.Spec.Settings; s != nil {
+		s.OpenstackPortAllowedStatuses = normalizeList(s.OpenstackPortAllowedStatuses, true)
+	}
+	return nil
+}
+
+// normalizeList는 공백을 제거하고 빈 값/중복을 없앤다. 입력 순서는 유지한다.
+func normalizeList(values []string, upper bool) []string {
+	if len(values) == 0 {
+		return values
+	}
+	out := make([]string, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if upper {
+			v = strings.ToUpper(v)
+		}
+		if _, ok := seen[v]; ok || v == "" {
+			continue
+		}
+		seen[v] = struct{}{}
+		out = append(out, v)
+	}
+	return out
+}
+
+// +kubebuilder:webhook:path=/validate-multinic-example-com-v1alpha1-openstackconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=multinic.example.com,resources=openstackconfigs,verbs=create;update,versions=v1alpha1,name=vopenstackconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// OpenstackConfigCustomValidator는 reconcile 시점에야 드러나던 설정 오류를 admission 단계에서 거절한다.
+type OpenstackConfigCustomValidator struct {
+	// Reader는 encrypt key Secret 존재 여부 확인에 사용한다. (nil이면 Secret 검증 생략)
+	Reader client.Reader
+}
+
+var _ webhook.CustomValidator = &OpenstackConfigCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type OpenstackConfig.
+func (v *OpenstackConfigCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	cfg, ok := obj.(*multinicv1alpha1.OpenstackConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a OpenstackConfig object but got %T", obj)
+	}
+	openstackconfiglog.V(1).Info("validation for OpenstackConfig upon creation", "name", cfg.GetName())
+	return v.validate(ctx, cfg)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type OpenstackConfig.
+// spec이 바뀌지 않은 갱신(finalizer 추가/해제 등)과 삭제 중인 CR은 검증하지 않는다.
+// 정규화만 된 경우도 spec 변경으로 보지 않도록 이전 spec도 정규화해 비교한다.
+func (v *OpenstackConfigCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	cfg, ok := newObj.(*multinicv1alpha1.OpenstackConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a OpenstackConfig object for the newObj but got %T", newObj)
+	}
+	old, ok := oldObj.(*multinicv1alpha1.OpenstackConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a OpenstackConfig object for the oldObj but got %T", oldObj)
+	}
+	openstackconfiglog.V(1).Info("validation for OpenstackConfig upon update", "name", cfg.GetName())
+	old = old.DeepCopy()
+	if err := (&OpenstackConfigCustomDefaulter{}).Default(ctx, old); err != nil {
+		return nil, err
+	}
+	if !cfg.DeletionTimestamp.IsZero() || equality.Semantic.DeepEqual(old.Spec, cfg.Spec) {
+		return nil, nil
+	}
+	return v.validate(ctx, cfg)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type OpenstackConfig.
+func (v *OpenstackConfigCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *OpenstackConfigCustomValidator) validate(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, validateSubnets(&cfg.Spec, specPath)...)
+	allErrs = append(allErrs, validateIDList(cfg.Spec.VmNames, specPath.Child("vmNames"))...)
+	if sel := cfg.Spec.VMSelector; sel != nil && strings.TrimSpace(sel.NameRegex) != "" {
+		if _, err := regexp.Compile(sel.NameRegex); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("vmSelector", "nameRegex"), sel.NameRegex, err.Error()))
+		}
+	}
+	allErrs = append(allErrs, validateSettings(cfg.Spec.Settings, specPath.Child("settings"))...)
+
+	// 필수 값은 OpenstackOperatorConfig 기본값을 겹친 결과로 판단한다.
+	base, err := v.operatorSettings(ctx, cfg)
+	if err != nil {
+		allErrs = append(allErrs, err)
+	}
+	merged := multinicv1alpha1.MergeSettings(base, cfg.Spec.Settings)
+	if strings.TrimSpace(merged.ContrabassEndpoint) == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("settings", "contrabassEndpoint"), "contrabass endpoint is required (set it here or in OpenstackOperatorConfig)"))
+	}
+
+	warnings, keyErrs := v.validateEncryptKey(ctx, cfg, merged, specPath)
+	allErrs = append(allErrs, keyErrs...)
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(multinicv1alpha1.GroupVersion.WithKind("OpenstackConfig").GroupKind(), cfg.Name, allErrs)
+}
+
+// validateSubnets는 subnetIDs/subnetID/subnetName 중 정확히 하나만 지정했는지 확인한다.
+func validateSubnets(spec *multinicv1alpha1.OpenstackConfigSpec, specPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	hasIDs := len(spec.SubnetIDs) > 0
+	hasID := strings.TrimSpace(spec.SubnetID) != ""
+	hasName := strings.TrimSpace(spec.SubnetName) != ""
+	switch {
+	case !hasIDs && !hasID && !hasName:
+		allErrs = append(allErrs, field.Required(specPath.Child("subnetIDs"), "one of subnetIDs, subnetID or subnetName is required"))
+	case hasIDs && (hasID || hasName):
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("subnetIDs"), "subnetIDs cannot be combined with subnetID or subnetName"))
+	case hasID && hasName:
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("subnetName"), "subnetName cannot be combined with subnetID"))
+	}
+	allErrs = append(allErrs, validateIDList(spec.SubnetIDs, specPath.Child("subnetIDs"))...)
+	if hasID && !uuidPattern.MatchString(strings.TrimSpace(spec.SubnetID)) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("subnetID"), spec.SubnetID, "must be a UUID"))
+	}
+	return allErrs
+}
+
+func validateIDList(values []string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, v := range values {
+		if !uuidPattern.MatchString(strings.TrimSpace(v)) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), v, "must be a UUID"))
+		}
+	}
+	return allErrs
+}
+
+func validateSettings(s *multinicv1alpha1.OpenstackConfigSettings, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if s == nil {
+		return nil
+	}
+	allErrs = append(allErrs, validateURL(s.ContrabassEndpoint, path.Child("contrabassEndpoint"))...)
+	allErrs = append(allErrs, validateURL(s.ViolaEndpoint, path.Child("violaEndpoint"))...)
+	allErrs = append(allErrs, validateURL(s.OpenstackNeutronEndpoint, path.Child("openstackNeutronEndpoint"))...)
+	allErrs = append(allErrs, validateURL(s.OpenstackNovaEndpoint, path.Child("openstackNovaEndpoint"))...)
+
+	if iface := strings.TrimSpace(s.OpenstackEndpointInterface); iface != "" && !containsString(endpointInterfaces, iface) {
+		allErrs = append(allErrs, field.NotSupported(path.Child("openstackEndpointInterface"), iface, endpointInterfaces))
+	}
+	for i, status := range s.OpenstackPortAllowedStatuses {
+		if strings.TrimSpace(status) == "" {
+			allErrs = append(allErrs, field.Invalid(path.Child("openstackPortAllowedStatuses").Index(i), status, "must not be empty"))
+		}
+	}
+	if s.DownPortFastRetryMax != nil && *s.DownPortFastRetryMax < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("downPortFastRetryMax"), *s.DownPortFastRetryMax, "must be at least 1"))
+	}
+
+	var errs field.ErrorList
+	_, errs = positiveDuration(s.ContrabassTimeout, path.Child("contrabassTimeout"))
+	allErrs = append(allErrs, errs...)
+	_, errs = positiveDuration(s.OpenstackTimeout, path.Child("openstackTimeout"))
+	allErrs = append(allErrs, errs...)
+	_, errs = positiveDuration(s.PollErrorInterval, path.Child("pollErrorInterval"))
+	allErrs = append(allErrs, errs...)
+	pollFast, errs := positiveDuration(s.PollFastInterval, path.Child("pollFastInterval"))
+	allErrs = append(allErrs, errs...)
+	pollSlow, errs := positiveDuration(s.PollSlowInterval, path.Child("pollSlowInterval"))
+	allErrs = append(allErrs, errs...)
+	if pollFast > 0 && pollSlow > 0 && pollSlow < pollFast {
+		allErrs = append(allErrs, field.Invalid(path.Child("pollSlowInterval"), s.PollSlowInterval, "must not be shorter than pollFastInterval"))
+	}
+	if s.HTTPRetryMaxAttempts != nil && *s.HTTPRetryMaxAttempts < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("httpRetryMaxAttempts"), *s.HTTPRetryMaxAttempts, "must be at least 1"))
+	}
+	retryBase, errs := positiveDuration(s.HTTPRetryBaseDelay, path.Child("httpRetryBaseDelay"))
+	allErrs = append(allErrs, errs...)
+	retryMax, errs := positiveDuration(s.HTTPRetryMaxDelay, path.Child("httpRetryMaxDelay"))
+	allErrs = append(allErrs, errs...)
+	if retryBase > 0 && retryMax > 0 && retryMax < retryBase {
+		allErrs = append(allErrs, field.Invalid(path.Child("httpRetryMaxDelay"), s.HTTPRetryMaxDelay, "must not be shorter than httpRetryBaseDelay"))
+	}
+	allErrs = append(allErrs, validateRetry(s.ContrabassRetry, path.Child("contrabassRetry"))...)
+	allErrs = append(allErrs, validateRetry(s.OpenstackRetry, path.Child("openstackRetry"))...)
+	allErrs = append(allErrs, validateRetry(s.ViolaRetry, path.Child("violaRetry"))...)
+	if v := strings.TrimSpace(s.PollFastWindow); v != "" {
+		if d, err := time.ParseDuration(v); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("pollFastWindow"), s.PollFastWindow, err.Error()))
+		} else if d < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("pollFastWindow"), s.PollFastWindow, "must not be negative"))
+		}
+	}
+	allErrs = append(allErrs, validateTLS(s.ContrabassTLS, path.Child("contrabassTLS"))...)
+	allErrs = append(allErrs, validateTLS(s.OpenstackTLS, path.Child("openstackTLS"))...)
+	allErrs = append(allErrs, validateTLS(s.ViolaTLS, path.Child("violaTLS"))...)
+	return allErrs
+}
+
+// validateRetry는 서비스별 재시도 정책 값을 검증한다.
+func validateRetry(r *multinicv1alpha1.HTTPRetrySettings, path *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	if r.MaxAttempts != nil && *r.MaxAttempts < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxAttempts"), *r.MaxAttempts, "must be at least 1"))
+	}
+	base, errs := positiveDuration(r.BaseDelay, path.Child("baseDelay"))
+	allErrs = append(allErrs, errs...)
+	maxDelay, errs := positiveDuration(r.MaxDelay, path.Child("maxDelay"))
+	allErrs = append(allErrs, errs...)
+	if base > 0 && maxDelay > 0 && maxDelay < base {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxDelay"), r.MaxDelay, "must not be shorter than baseDelay"))
+	}
+	return allErrs
+}
+
+// validateTLS는 CA bundle 참조의 종류와 이름을 검증한다.
+func validateTLS(t *multinicv1alpha1.TLSSettings, path *field.Path) field.ErrorList {
+	if t == nil || t.CABundleRef == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	refPath := path.Child("caBundleRef")
+	kinds := []string{multinicv1alpha1.CABundleKindSecret, multinicv1alpha1.CABundleKindConfigMap}
+	if kind := strings.TrimSpace(t.CABundleRef.Kind); kind != "" && !containsString(kinds, kind) {
+		allErrs = append(allErrs, field.NotSupported(refPath.Child("kind"), kind, kinds))
+	}
+	if strings.TrimSpace(t.CABundleRef.Name) == "" {
+		allErrs = append(allErrs, field.Required(refPath.Child("name"), "must be set"))
+	}
+	return allErrs
+}
+
+// positiveDuration은 값이 비어 있으면 0을, 형식 오류나 0 이하이면 field 오류를 반환한다.
+func positiveDuration(value string, path *field.Path) (time.Duration, field.ErrorList) {
+	v := strings.TrimSpace(value)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, field.ErrorList{field.Invalid(path, value, err.Error())}
+	}
+	if d <= 0 {
+		return 0, field.ErrorList{field.Invalid(path, value, "must be positive")}
+	}
+	return d, nil
+}
+
+func validateURL(value string, path *field.Path) field.ErrorList {
+	v := strings.TrimSpace(value)
+	if v == "" {
+		return nil
+	}
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return field.ErrorList{field.Invalid(path, value, "must be an absolute http(s) URL")}
+	}
+	return nil
+}
+
+// operatorSettings는 CR이 참조하는 OpenstackOperatorConfig settings를 조회한다.
+// 기본 이름("default")은 없어도 되지만, 명시한 이름이 없으면 field 오류를 반환한다.
+func (v *OpenstackConfigCustomValidator) operatorSettings(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig) (*multinicv1alpha1.OpenstackConfigSettings, *field.Error) {
+	if v.Reader == nil {
+		return nil, nil
+	}
+	namePath := field.NewPath("spec", "operatorConfigName")
+	name := strings.TrimSpace(cfg.Spec.OperatorConfigName)
+	explicit := name != ""
+	if !explicit {
+		name = multinicv1alpha1.DefaultOperatorConfigName
+	}
+	var opCfg multinicv1alpha1.OpenstackOperatorConfig
+	if err := v.Reader.Get(ctx, types.NamespacedName{Name: name}, &opCfg); err != nil {
+		switch {
+		case apierrors.IsNotFound(err) && explicit:
+			return nil, field.NotFound(namePath, name)
+		case apierrors.IsNotFound(err) || meta.IsNoMatchError(err):
+			return nil, nil
+		}
+		return nil, field.InternalError(namePath, err)
+	}
+	return opCfg.Spec.Settings, nil
+}
+
+// validateEncryptKey는 resolveContrabassEncryptKey와 같은 순서(SecretRef → 기본 Secret → settings)로
+// 사용할 수 있는 encrypt key가 있는지 확인한다. settings는 오퍼레이터 기본값을 겹친 값이다.
+// Secret은 CR과 함께(또는 나중에) 만들어질 수 있으므로 없으면 거절하지 않고 경고만 한다.
+// 그 사이에는 reconcile이 Ready=False(ConfigError)로 표시하고, Secret이 생성되면 바로 재조정한다.
+func (v *OpenstackConfigCustomValidator) validateEncryptKey(ctx context.Context, cfg *multinicv1alpha1.OpenstackConfig, merged *multinicv1alpha1.OpenstackConfigSettings, specPath *field.Path) (admission.Warnings, field.ErrorList) {
+	inline := strings.TrimSpace(merged.ContrabassEncryptKey) != ""
+	var warnings admission.Warnings
+	if cfg.Spec.Settings != nil && strings.TrimSpace(cfg.Spec.Settings.ContrabassEncryptKey) != "" {
+		warnings = append(warnings, "spec.settings.contrabassEncryptKey is stored in plain text; prefer spec.secrets.contrabassEncryptKeySecretRef")
+	}
+
+	if cfg.Spec.Secrets != nil && cfg.Spec.Secrets.ContrabassEncryptKeySecretRef != nil {
+		ref := cfg.Spec.Secrets.ContrabassEncryptKeySecretRef
+		refPath := specPath.Child("secrets", "contrabassEncryptKeySecretRef")
+		if v.Reader == nil {
+			return warnings, nil
+		}
+		found, hasKey, err := v.secretHasKey(ctx, cfg.Namespace, ref.Name, ref.Key)
+		switch {
+		case err != nil:
+			return warnings, field.ErrorList{field.InternalError(refPath, err)}
+		case !found:
+			warnings = append(warnings, fmt.Sprintf("%s: Secret %s/%s not found; sync stays pending until it is created", refPath, cfg.Namespace, ref.Name))
+		case !hasKey:
+			warnings = append(warnings, fmt.Sprintf("%s: Secret %s/%s has no key %q; sync stays pending until it is set", refPath, cfg.Namespace, ref.Name, ref.Key))
+		}
+		return warnings, nil
+	}
+	if inline || v.Reader == nil {
+		return warnings, nil
+	}
+	_, hasKey, err := v.secretHasKey(ctx, cfg.Namespace, multinicv1alpha1.DefaultContrabassEncryptKeySecretName, multinicv1alpha1.DefaultContrabassEncryptKeySecretKey)
+	if err != nil {
+		return warnings, field.ErrorList{field.InternalError(specPath.Child("secrets"), err)}
+	}
+	if !hasKey {
+		warnings = append(warnings, fmt.Sprintf(
+			"contrabass encrypt key not found; sync stays pending until spec.secrets.contrabassEncryptKeySecretRef, %s/%s Secret with key %s, or spec.settings.contrabassEncryptKey is set",
+			cfg.Namespace, multinicv1alpha1.DefaultContrabassEncryptKeySecretName, multinicv1alpha1.DefaultContrabassEncryptKeySecretKey))
+	}
+	return warnings, nil
+}
+
+// secretHasKey는 Secret 존재 여부와 비어 있지 않은 key 포함 여부를 반환한다.
+func (v *OpenstackConfigCustomValidator) secretHasKey(ctx context.Context, namespace, name, key string) (found, hasKey bool, err error) {
+	var secret corev1.Secret
+	if err := v.Reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: strings.TrimSpace(name)}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	value, ok := secret.Data[strings.TrimSpace(key)]
+	return true, ok && strings.TrimSpace(string(value)) != "", nil
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	multinicv1alpha1 "multinic-operator/api/v1alpha1"
+)
+
+func validConfig() *multinicv1alpha1.OpenstackConfig {
+	return &multinicv1alpha1.OpenstackConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "cfg", Namespace: "multinic-system"},
+		Spec: multinicv1alpha1.OpenstackConfigSpec{
+			SubnetIDs: []string{"8f0d5f5b-8f3f-4b2b-9c4c-8c9f7c36d1f2"},
+			VmNames:   []string{"08186d75-754e-449c-b210-c0ea822727a7"},
+			Credentials: multinicv1alpha1.OpenstackCredentials{
+				OpenstackProviderID: "provider-1",
+				K8sProviderID:       "k8s-provider-1",
+				ProjectID:           "project-1",
+			},
+			Settings: &multinicv1alpha1.OpenstackConfigSettings{
+				ContrabassEndpoint: "https://contrabass.example.com",
+			},
+			Secrets: &multinicv1alpha1.OpenstackConfigSecrets{
+				ContrabassEncryptKeySecretRef: &multinicv1alpha1.SecretKeyRef{Name: "enc", Key: "KEY"},
+			},
+		},
+	}
+}
+
+func newValidator(t *testing.T, objs ...runtime.Object) *OpenstackConfigCustomValidator {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	if err := multinicv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("add scheme: %v", err)
+	}
+	return &OpenstackConfigCustomValidator{Reader: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()}
+}
+
+func encryptKeySecret(name, key string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "multinic-system"},
+		Data:       map[string][]byte{key: []byte("secret")},
+	}
+}
+
+// causeFields는 Invalid 오류의 field 경로 목록을 반환한다.
+func causeFields(t *testing.T, err error) []string {
+	t.Helper()
+	statusErr, ok := err.(*apierrors.StatusError)
+	if !ok || !apierrors.IsInvalid(err) {
+		t.Fatalf("expected Invalid status error, got %v", err)
+	}
+	var fields []string
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		fields = append(fields, cause.Field)
+	}
+	return fields
+}
+
+func TestDefault_NormalizesLists(t *testing.T) {
+	cfg := validConfig()
+	cfg.Spec.VmNames = []string{" 08186d75-754e-449c-b210-c0ea822727a7", "08186d75-754e-449c-b210-c0ea822727a7", ""}
+	cfg.Spec.Settings.OpenstackPortAllowedStatuses = []string{"active", " down", "ACTIVE"}
+	if err := (&OpenstackConfigCustomDefaulter{}).Default(context.Background(), cfg); err != nil {
+		t.Fatalf("Default error: %v", err)
+	}
+	if len(cfg.Spec.VmNames) != 1 || cfg.Spec.VmNames[0] != "08186d75-754e-449c-b210-c0ea822727a7" {
+		t.Fatalf("unexpected vmNames: %v", cfg.Spec.VmNames)
+	}
+	s := cfg.Spec.Settings
+	if strings.Join(s.OpenstackPortAllowedStatuses, ",") != "ACTIVE,DOWN" {
+		t.Fatalf("unexpected allowed statuses: %v", s.OpenstackPortAllowedStatuses)
+	}
+	// 기본값은 OpenstackOperatorConfig가 가려지지 않도록 CR에 기록하지 않는다.
+	if s.PollFastInterval != "" || s.ContrabassTimeout != "" || s.DownPortFastRetryMax != nil {
+		t.Fatalf("expected settings defaults to stay unset, got %+v", s)
+	}
+}
+
+func TestValidateCreate_Valid(t *testing.T) {
+	v := newValidator(t, encryptKeySecret("enc", "KEY"))
+	cfg := validConfig()
+	if err := (&OpenstackConfigCustomDefaulter{}).Default(context.Background(), cfg); err != nil {
+		t.Fatalf("Default error: %v", err)
+	}
+	if _, err := v.ValidateCreate(context.Background(), cfg); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidateCreate_FieldErrors(t *testing.T) {
+	v := newValidator(t, encryptKeySecret("enc", "KEY"))
+	cfg := validConfig()
+	cfg.Spec.SubnetID = "8f0d5f5b-8f3f-4b2b-9c4c-8c9f7c36d1f2"
+	cfg.Spec.VmNames = []string{"08186d75-754e-449c-b210-c0ea822727a7", "worker-1"}
+	cfg.Spec.Settings.PollFastInterval = "fast"
+	cfg.Spec.Settings.PollErrorInterval = "0s"
+	cfg.Spec.Settings.OpenstackEndpointInterface = "private"
+	cfg.Spec.Settings.HTTPRetryBaseDelay = "2s"
+	cfg.Spec.Settings.HTTPRetryMaxDelay = "1s"
+	noAttempts := int32(0)
+	cfg.Spec.Settings.ViolaRetry = &multinicv1alpha1.HTTPRetrySettings{MaxAttempts: &noAttempts, BaseDelay: "soon"}
+	cfg.Spec.Settings.ViolaTLS = &multinicv1alpha1.TLSSettings{CABundleRef: &multinicv1alpha1.CABundleRef{Kind: "Node"}}
+
+	_, err := v.ValidateCreate(context.Background(), cfg)
+	got := strings.Join(causeFields(t, err), ",")
+	for _, want := range []string{
+		"spec.subnetIDs",
+		"spec.vmNames[1]",
+		"spec.settings.pollFastInterval",
+		"spec.settings.pollErrorInterval",
+		"spec.settings.openstackEndpointInterface",
+		"spec.settings.httpRetryMaxDelay",
+		"spec.settings.violaRetry.maxAttempts",
+		"spec.settings.violaRetry.baseDelay",
+		"spec.settings.violaTLS.caBundleRef.kind",
+		"spec.settings.violaTLS.caBundleRef.name",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected field error for %s, got %s", want, got)
+		}
+	}
+}
+
+func TestValidateCreate_PollSlowShorterThanFast(t *testing.T) {
+	v := newValidator(t, encryptKeySecret("enc", "KEY"))
+	cfg := validConfig()
+	cfg.Spec.Settings.PollFastInterval = "1m"
+	cfg.Spec.Settings.PollSlowInterval = "30s"
+	_, err := v.ValidateCreate(context.Background(), cfg)
+	if fields := causeFields(t, err); len(fields) != 1 || fields[0] != "spec.settings.pollSlowInterval" {
+		t.Fatalf("unexpected field errors: %v", fields)
+	}
+}
+
+func TestValidateCreate_EncryptKey(t *testing.T) {
+	// SecretRef가 가리키는 Secret이 아직 없으면 허용하되 경고한다. (CR과 함께 생성하는 경우)
+	cfg := validConfig()
+	warnings, err := newValidator(t).ValidateCreate(context.Background(), cfg)
+	if err != nil || len(warnings) != 1 || !strings.Contains(warnings[0], "spec.secrets.contrabassEncryptKeySecretRef") {
+		t.Fatalf("expected missing secret accepted with warning, got warnings=%v err=%v", warnings, err)
+	}
+	if warnings, err := newValidator(t, encryptKeySecret("enc", "OTHER")).ValidateCreate(context.Background(), cfg); err != nil || len(warnings) != 1 {
+		t.Fatalf("expected missing key accepted with warning, got warnings=%v err=%v", warnings, err)
+	}
+
+	// SecretRef가 없으면 기본 Secret을 찾는다.
+	cfg.Spec.Secrets = nil
+	warnings, err = newValidator(t).ValidateCreate(context.Background(), cfg)
+	if err != nil || len(warnings) != 1 || !strings.Contains(warnings[0], multinicv1alpha1.DefaultContrabassEncryptKeySecretName) {
+		t.Fatalf("expected missing default secret accepted with warning, got warnings=%v err=%v", warnings, err)
+	}
+	defaultSecret := encryptKeySecret(multinicv1alpha1.DefaultContrabassEncryptKeySecretName, multinicv1alpha1.DefaultContrabassEncryptKeySecretKey)
+	if warnings, err := newValidator(t, defaultSecret).ValidateCreate(context.Background(), cfg); err != nil || len(warnings) != 0 {
+		t.Fatalf("expected default secret to satisfy encrypt key, got warnings=%v err=%v", warnings, err)
+	}
+
+	// settings에 직접 넣은 키는 허용하되 경고한다.
+	cfg.Spec.Settings.ContrabassEncryptKey = "inline-key"
+	warnings, err = newValidator(t).ValidateCreate(context.Background(), cfg)
+	if err != nil || len(warnings) != 1 {
+		t.Fatalf("expected inline key accepted with warning, got warnings=%v err=%v", warnings, err)
+	}
+}
+
+func TestValidateUpdate_SkipsUnchangedSpec(t *testing.T) {
+	v := newValidator(t)
+	old := validConfig()
+	old.Spec.VmNames = []string{"worker-1"}
+	updated := old.DeepCopy()
+	updated.Finalizers = []string{"multinic.example.com/finalizer"}
+	if err := (&OpenstackConfigCustomDefaulter{}).Default(context.Background(), updated); err != nil {
+		t.Fatalf("Default error: %v", err)
+	}
+	// 기본값만 채워진 갱신(finalizer 추가)은 기존 CR이 잘못되었어도 막지 않는다.
+	if _, err := v.ValidateUpdate(context.Background(), old, updated); err != nil {
+		t.Fatalf("expected unchanged spec to be allowed, got %v", err)
+	}
+
+	updated.Spec.VmNames = append(updated.Spec.VmNames, "worker-2")
+	if _, err := v.ValidateUpdate(context.Background(), old, updated); err == nil {
+		t.Fatalf("expected changed spec to be validated")
+	}
+}
+
+func TestValidateCreate_OperatorConfigDefaults(t *testing.T) {
+	opCfg := &multinicv1alpha1.OpenstackOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: multinicv1alpha1.DefaultOperatorConfigName},
+		Spec: multinicv1alpha1.OpenstackOperatorConfigSpec{Settings: &multinicv1alpha1.OpenstackConfigSettings{
+			ContrabassEndpoint: "https://contrabass.example.com",
+		}},
+	}
+	cfg := validConfig()
+	cfg.Spec.Settings = nil
+
+	// contrabassEndpoint가 CR과 OpenstackOperatorConfig 모두에 없으면 거절한다.
+	_, err := newValidator(t, encryptKeySecret("enc", "KEY")).ValidateCreate(context.Background(), cfg)
+	if fields := causeFields(t, err); len(fields) != 1 || fields[0] != "spec.settings.contrabassEndpoint" {
+		t.Fatalf("unexpected field errors: %v", fields)
+	}
+	if _, err := newValidator(t, encryptKeySecret("enc", "KEY"), opCfg).ValidateCreate(context.Background(), cfg); err != nil {
+		t.Fatalf("expected operator default endpoint to be accepted, got %v", err)
+	}
+
+	// 명시한 OpenstackOperatorConfig가 없으면 거절한다.
+	cfg.Spec.OperatorConfigName = "missing"
+	_, err = newValidator(t, encryptKeySecret("enc", "KEY"), opCfg).ValidateCreate(context.Background(), cfg)
+	got := strings.Join(causeFields(t, err), ",")
+	if !strings.Contains(got, "spec.operatorConfigName") {
+		t.Fatalf("expected operatorConfigName error, got %s", got)
+	}
+}
@@ -0,0 +1,194 @@
+// Package apierror는 OpenStack/Contrabass/Viola HTTP 응답 오류를 공통 형식으로 표현한다.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 상태 코드별 sentinel. errors.Is(err, ErrNotFound)처럼 사용한다.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+)
+
+// maxBodyBytes는 오류 응답 본문을 읽는 최대 크기이다.
+const maxBodyBytes = 64 << 10
+
+// maxMessageLen은 조건 메시지에 넣을 fault 메시지 최대 길이이다.
+const maxMessageLen = 512
+
+// requestIDHeaders는 요청 ID를 찾는 헤더 순서이다.
+var requestIDHeaders = []string{"X-Openstack-Request-Id", "X-Compute-Request-Id", "X-Request-Id"}
+
+// Error는 예상하지 못한 HTTP 응답을 나타낸다.
+type Error struct {
+	// Service는 호출한 서비스 이름이다. (keystone, neutron, nova, contrabass, viola)
+	Service string
+	// Operation은 호출 목적이다. (예: "list ports")
+	Operation string
+	// StatusCode는 HTTP 응답 코드이다.
+	StatusCode int
+	// RequestID는 X-Openstack-Request-Id 등 서버가 돌려준 요청 ID이다.
+	RequestID string
+	// FaultType은 fault 본문의 종류이다. (예: itemNotFound, PortNotFound)
+	FaultType string
+	// Message는 fault 본문에서 추출한 메시지이다. 해석할 수 없으면 본문 앞부분을 사용한다.
+	Message string
+	// RetryAfter는 Retry-After 헤더 값이다. (없으면 0)
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Service)
+	if e.Operation != "" {
+		b.WriteString(" " + e.Operation)
+	}
+	fmt.Fprintf(&b, ": unexpected status %d", e.StatusCode)
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request %s)", e.RequestID)
+	}
+	if e.FaultType != "" && e.Message != "" {
+		fmt.Fprintf(&b, ": %s: %s", e.FaultType, e.Message)
+	} else if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	return b.String()
+}
+
+// Is는 상태 코드에 해당하는 sentinel과 비교한다.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return false
+}
+
+// Temporary는 재시도로 회복될 수 있는 응답(429, 5xx)인지 반환한다.
+func (e *Error) Temporary() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// FromResponse는 응답 헤더/본문으로 Error를 만든다. 본문은 최대 maxBodyBytes까지 읽는다.
+func FromResponse(service, operation string, resp *http.Response) *Error {
+	e := &Error{
+		Service:    service,
+		Operation:  operation,
+		StatusCode: resp.StatusCode,
+		RetryAfter: ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+	for _, h := range requestIDHeaders {
+		if v := strings.TrimSpace(resp.Header.Get(h)); v != "" {
+			e.RequestID = v
+			break
+		}
+	}
+	if resp.Body != nil {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+		e.FaultType, e.Message = parseFault(body)
+	}
+	return e
+}
+
+// As는 err에서 *Error를 꺼낸다.
+func As(err error) (*Error, bool) {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// StatusCode는 err가 *Error이면 응답 코드를, 아니면 0을 반환한다.
+func StatusCode(err error) int {
+	if apiErr, ok := As(err); ok {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// ParseRetryAfter는 Retry-After 헤더(초 또는 HTTP-date)를 해석한다.
+func ParseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// parseFault는 서비스별 fault 본문에서 종류와 메시지를 추출한다.
+//
+//	nova:       {"itemNotFound": {"code": 404, "message": "..."}}
+//	neutron:    {"NeutronError": {"type": "PortNotFound", "message": "...", "detail": ""}}
+//	keystone:   {"error": {"code": 401, "title": "Unauthorized", "message": "..."}}
+//	그 외:      {"message": "..."} / {"error": "..."} / 일반 텍스트
+func parseFault(body []byte) (string, string) {
+	body = []byte(strings.TrimSpace(string(body)))
+	if len(body) == 0 {
+		return "", ""
+	}
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(body, &top); err != nil {
+		return "", truncate(string(body))
+	}
+	for _, key := range []string{"message", "error", "detail", "faultstring"} {
+		var s string
+		if raw, ok := top[key]; ok && json.Unmarshal(raw, &s) == nil && s != "" {
+			return "", truncate(s)
+		}
+	}
+	for key, raw := range top {
+		var fault struct {
+			Type    string `json:"type"`
+			Title   string `json:"title"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(raw, &fault) != nil || fault.Message == "" {
+			continue
+		}
+		kind := key
+		switch {
+		case fault.Type != "":
+			kind = fault.Type
+		case fault.Title != "":
+			kind = fault.Title
+		}
+		return kind, truncate(fault.Message)
+	}
+	return "", truncate(string(body))
+}
+
+func truncate(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > maxMessageLen {
+		return string(r[:maxMessageLen]) + "..."
+	}
+	return s
+}
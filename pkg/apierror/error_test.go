@@ -0,0 +1,96 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func response(code int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{StatusCode: code, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestFromResponse_ParsesFaults(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		wantType  string
+		wantMsg   string
+		wantError string
+	}{
+		{
+			name:      "nova",
+			body:      `{"itemNotFound": {"code": 404, "message": "Instance vm-1 could not be found."}}`,
+			wantType:  "itemNotFound",
+			wantMsg:   "Instance vm-1 could not be found.",
+			wantError: "nova get server: unexpected status 404 (request req-1): itemNotFound: Instance vm-1 could not be found.",
+		},
+		{
+			name:     "neutron",
+			body:     `{"NeutronError": {"type": "PortNotFound", "message": "Port p1 could not be found.", "detail": ""}}`,
+			wantType: "PortNotFound",
+			wantMsg:  "Port p1 could not be found.",
+		},
+		{
+			name:     "keystone",
+			body:     `{"error": {"code": 401, "title": "Unauthorized", "message": "The request you have made requires authentication."}}`,
+			wantType: "Unauthorized",
+			wantMsg:  "The request you have made requires authentication.",
+		},
+		{name: "message", body: `{"message": "provider not found"}`, wantMsg: "provider not found"},
+		{name: "text", body: "upstream\n connect error", wantMsg: "upstream connect error"},
+		{name: "empty", body: ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("X-Openstack-Request-Id", "req-1")
+			err := FromResponse("nova", "get server", response(http.StatusNotFound, header, tc.body))
+			if err.FaultType != tc.wantType || err.Message != tc.wantMsg {
+				t.Fatalf("unexpected fault: type=%q message=%q", err.FaultType, err.Message)
+			}
+			if err.RequestID != "req-1" {
+				t.Fatalf("expected request id, got %q", err.RequestID)
+			}
+			if tc.wantError != "" && err.Error() != tc.wantError {
+				t.Fatalf("unexpected error string: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestError_IsAndTemporary(t *testing.T) {
+	unauthorized := fmt.Errorf("wrapped: %w", FromResponse("neutron", "list ports", response(http.StatusUnauthorized, nil, "")))
+	if !errors.Is(unauthorized, ErrUnauthorized) || errors.Is(unauthorized, ErrNotFound) {
+		t.Fatalf("unexpected errors.Is result for 401")
+	}
+	if StatusCode(unauthorized) != http.StatusUnauthorized || StatusCode(errors.New("dial")) != 0 {
+		t.Fatalf("unexpected StatusCode result")
+	}
+	header := http.Header{}
+	header.Set("Retry-After", "120")
+	throttled := FromResponse("nova", "list servers", response(http.StatusTooManyRequests, header, ""))
+	if !throttled.Temporary() || throttled.RetryAfter != 2*time.Minute {
+		t.Fatalf("expected temporary 429 with Retry-After, got %+v", throttled)
+	}
+	if FromResponse("nova", "get server", response(http.StatusConflict, nil, "")).Temporary() {
+		t.Fatalf("expected 409 to be non-temporary")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := ParseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now); got != 30*time.Second {
+		t.Fatalf("expected 30s from HTTP-date, got %s", got)
+	}
+	if got := ParseRetryAfter("soon", now); got != 0 {
+		t.Fatalf("expected 0 for invalid value, got %s", got)
+	}
+}
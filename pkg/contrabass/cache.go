@@ -0,0 +1,84 @@
+package contrabass
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type providerKey struct {
+	baseURL    string
+	providerID string
+}
+
+type providerEntry struct {
+	provider    Provider
+	adminID     string
+	adminPwEnc  string
+	rabbitPwEnc string
+	encryptKey  string
+	fetchedAt   time.Time
+}
+
+// ProviderCache는 providerID 단위로 Contrabass 조회 결과를 TTL 동안 재사용한다.
+// 같은 openstackProviderID를 쓰는 CR끼리 공유하며, TTL이 지나면 다시 조회해
+// 암호문이 그대로면 복호화를 생략하고, 바뀌었으면 자격증명 변경(rotation)으로 판단한다.
+type ProviderCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[providerKey]providerEntry
+}
+
+// NewProviderCache는 TTL이 0 이하이면 매번 조회하되 복호화 생략과 변경 감지는 유지한다.
+func NewProviderCache(ttl time.Duration) *ProviderCache {
+	return &ProviderCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[providerKey]providerEntry),
+	}
+}
+
+// GetProvider는 캐시된 Provider를 반환하고, 자격증명이 바뀐 경우 rotated=true를 함께 반환한다.
+func (pc *ProviderCache) GetProvider(ctx context.Context, c *Client, providerID string) (*Provider, bool, error) {
+	key := providerKey{baseURL: c.baseURL, providerID: providerID}
+	pc.mu.Lock()
+	entry, ok := pc.entries[key]
+	pc.mu.Unlock()
+	now := pc.now()
+	if ok && entry.encryptKey == c.encryptKey && pc.ttl > 0 && now.Sub(entry.fetchedAt) < pc.ttl {
+		provider := entry.provider
+		return &provider, false, nil
+	}
+
+	data, err := c.fetchProvider(ctx, providerID)
+	if err != nil {
+		return nil, false, err
+	}
+	unchanged := ok && entry.encryptKey == c.encryptKey &&
+		entry.adminPwEnc == data.Attributes.AdminPw &&
+		entry.rabbitPwEnc == data.Attributes.RabbitMQPw
+	var provider *Provider
+	if unchanged {
+		provider = newProvider(data, entry.provider.AdminPass, entry.provider.RabbitPass)
+	} else {
+		provider, err = c.decodeProvider(data)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	rotated := ok && (entry.adminPwEnc != data.Attributes.AdminPw || entry.adminID != data.Attributes.AdminID)
+
+	pc.mu.Lock()
+	pc.entries[key] = providerEntry{
+		provider:    *provider,
+		adminID:     data.Attributes.AdminID,
+		adminPwEnc:  data.Attributes.AdminPw,
+		rabbitPwEnc: data.Attributes.RabbitMQPw,
+		encryptKey:  c.encryptKey,
+		fetchedAt:   now,
+	}
+	pc.mu.Unlock()
+	return provider, rotated, nil
+}
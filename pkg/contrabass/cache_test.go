@@ -0,0 +1,76 @@
+package contrabass
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+const testEncryptKey = "conbaEncrypt2025"
+
+func encryptForTest(t *testing.T, plain string) string {
+	t.Helper()
+	block, err := aes.NewCipher([]byte(testEncryptKey))
+	if err != nil {
+		t.Fatalf("cipher: %v", err)
+	}
+	pad := aes.BlockSize - len(plain)%aes.BlockSize
+	data := append([]byte(plain), bytes.Repeat([]byte{byte(pad)}, pad)...)
+	iv := bytes.Repeat([]byte{0x01}, aes.BlockSize)
+	out := make([]byte, len(data))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(out, data)
+	return base64.StdEncoding.EncodeToString(append(iv, out...))
+}
+
+func TestProviderCache(t *testing.T) {
+	adminPw := encryptForTest(t, "first-password")
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = fmt.Fprintf(w, `{"data":{"url":"https://keystone:5000","attributes":{"adminId":"admin","adminPw":%q,"domain":"default"}}}`, adminPw)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, testEncryptKey, 5*time.Second)
+	cache := NewProviderCache(time.Minute)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		got, rotated, err := cache.GetProvider(context.Background(), c, "provider-1")
+		if err != nil {
+			t.Fatalf("GetProvider error: %v", err)
+		}
+		if rotated || got.AdminPass != "first-password" {
+			t.Fatalf("unexpected provider: rotated=%v pass=%q", rotated, got.AdminPass)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("expected cached lookup within TTL, got %d requests", requests)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, rotated, err := cache.GetProvider(context.Background(), c, "provider-1"); err != nil || rotated {
+		t.Fatalf("expected refresh without rotation, got rotated=%v err=%v", rotated, err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected refetch after TTL, got %d requests", requests)
+	}
+
+	adminPw = encryptForTest(t, "second-password")
+	now = now.Add(2 * time.Minute)
+	got, rotated, err := cache.GetProvider(context.Background(), c, "provider-1")
+	if err != nil {
+		t.Fatalf("GetProvider error: %v", err)
+	}
+	if !rotated || got.AdminPass != "second-password" {
+		t.Fatalf("expected rotation to be detected, got rotated=%v pass=%q", rotated, got.AdminPass)
+	}
+}
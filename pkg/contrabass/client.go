@@ -5,12 +5,14 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net"
 	"net/http"
 	"strings"
 	"time"
 
+	"multinic-operator/pkg/apierror"
 	"multinic-operator/pkg/crypto"
+	"multinic-operator/pkg/httpretry"
+	"multinic-operator/pkg/httptransport"
 )
 
 type Client struct {
@@ -19,6 +21,9 @@ type Client struct {
 	httpClient  *http.Client
 	authToken   string
 	insecureTLS bool
+	tlsConfig   *tls.Config
+	transport   http.RoundTripper
+	retry       httpretry.Policy
 }
 
 type Option func(*Client)
@@ -31,6 +36,21 @@ func WithInsecureTLS(insecure bool) Option {
 	return func(c *Client) { c.insecureTLS = insecure }
 }
 
+// WithTLSConfig는 CA bundle/클라이언트 인증서를 담은 TLS 설정을 지정한다.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) { c.tlsConfig = cfg }
+}
+
+// WithTransport는 공유 transport를 사용하게 한다. 지정하면 TLS 관련 옵션은 무시된다.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) { c.transport = rt }
+}
+
+// WithRetry는 provider 조회(GET) 재시도 정책을 지정한다.
+func WithRetry(policy httpretry.Policy) Option {
+	return func(c *Client) { c.retry = policy }
+}
+
 func NewClient(baseURL, encryptKey string, timeout time.Duration, opts ...Option) *Client {
 	c := &Client{
 		baseURL:    baseURL,
@@ -39,16 +59,12 @@ func NewClient(baseURL, encryptKey string, timeout time.Duration, opts ...Option
 	for _, opt := range opts {
 		opt(c)
 	}
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: c.insecureTLS}, //nolint:gosec
-		DialContext: (&net.Dialer{
-			Timeout:   5 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		ForceAttemptHTTP2: true,
+	rt := c.transport
+	if rt == nil {
+		rt = httptransport.New(c.tlsConfig, c.insecureTLS)
 	}
 	c.httpClient = &http.Client{
-		Transport: tr,
+		Transport: httpretry.NewTransport(rt, c.retry),
 		Timeout:   timeout,
 	}
 	return c
@@ -92,48 +108,64 @@ type Provider struct {
 
 // GetProvider는 Contrabass에서 OpenStack 접속 정보를 조회한다.
 func (c *Client) GetProvider(ctx context.Context, providerID string) (*Provider, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/contrabass/admin/infra/provider/%s", c.baseURL, providerID), http.NoBody)
+	data, err := c.fetchProvider(ctx, providerID)
 	if err != nil {
 		return nil, err
 	}
+	return c.decodeProvider(data)
+}
+
+// fetchProvider는 복호화 전의 provider 응답을 조회한다.
+func (c *Client) fetchProvider(ctx context.Context, providerID string) (providerData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/contrabass/admin/infra/provider/%s", c.baseURL, providerID), http.NoBody)
+	if err != nil {
+		return providerData{}, err
+	}
 	if c.authToken != "" {
 		req.Header.Set("Authorization", "Bearer "+c.authToken)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return providerData{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("contrabass: unexpected status %d", resp.StatusCode)
+		return providerData{}, apierror.FromResponse("contrabass", "get provider", resp)
 	}
 
 	var out providerResponse
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return nil, err
+		return providerData{}, err
 	}
+	return out.Data, nil
+}
 
-	adminPass, err := crypto.DecryptAESCBC(out.Data.Attributes.AdminPw, c.encryptKey)
+// decodeProvider는 암호화된 비밀번호를 복호화해 Provider를 만든다.
+func (c *Client) decodeProvider(data providerData) (*Provider, error) {
+	adminPass, err := crypto.DecryptAESCBC(data.Attributes.AdminPw, c.encryptKey)
 	if err != nil {
 		return nil, fmt.Errorf("contrabass: decrypt adminPw: %w", err)
 	}
 	rabbitPass := ""
-	if strings.TrimSpace(out.Data.Attributes.RabbitMQPw) != "" {
-		rabbitPass, err = crypto.DecryptAESCBC(out.Data.Attributes.RabbitMQPw, c.encryptKey)
+	if strings.TrimSpace(data.Attributes.RabbitMQPw) != "" {
+		rabbitPass, err = crypto.DecryptAESCBC(data.Attributes.RabbitMQPw, c.encryptKey)
 		if err != nil {
 			return nil, fmt.Errorf("contrabass: decrypt rabbitMQPw: %w", err)
 		}
 	}
+	return newProvider(data, adminPass, rabbitPass), nil
+}
 
+func newProvider(data providerData, adminPass, rabbitPass string) *Provider {
 	return &Provider{
-		KeystoneURL: out.Data.URL,
-		AdminID:     out.Data.Attributes.AdminID,
+		KeystoneURL: data.URL,
+		AdminID:     data.Attributes.AdminID,
 		AdminPass:   adminPass,
-		Domain:      out.Data.Attributes.Domain,
-		RabbitUser:  out.Data.Attributes.RabbitMQID,
+		Domain:      data.Attributes.Domain,
+		RabbitUser:  data.Attributes.RabbitMQID,
 		RabbitPass:  rabbitPass,
-		RabbitURLs:  out.Data.Attributes.RabbitMQURL,
-	}, nil
+		RabbitURLs:  data.Attributes.RabbitMQURL,
+	}
 }
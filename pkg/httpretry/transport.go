@@ -0,0 +1,172 @@
+// Package httpretry는 HTTP 클라이언트용 재시도 RoundTripper를 제공한다.
+// 429/5xx 응답과 연결 오류를 지수 백오프(+jitter)로 재시도하며, Retry-After를 따른다.
+package httpretry
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"multinic-operator/pkg/apierror"
+)
+
+// 기본 재시도 정책 값.
+const (
+	DefaultMaxAttempts = 3
+	DefaultBaseDelay   = 500 * time.Millisecond
+	DefaultMaxDelay    = 10 * time.Second
+)
+
+// drainLimit은 재시도 전 연결 재사용을 위해 버리는 응답 본문 최대 크기이다.
+const drainLimit = 64 << 10
+
+// Policy는 재시도 횟수와 대기 시간을 정한다.
+type Policy struct {
+	// MaxAttempts는 첫 시도를 포함한 최대 시도 횟수이다. 1 이하이면 재시도하지 않는다.
+	MaxAttempts int
+	// BaseDelay는 첫 재시도 전 대기 시간이다. 이후 두 배씩 늘어난다.
+	BaseDelay time.Duration
+	// MaxDelay는 재시도 간 최대 대기 시간이다. Retry-After가 이보다 길면 재시도하지 않는다.
+	MaxDelay time.Duration
+}
+
+// DefaultPolicy는 기본 재시도 정책을 반환한다.
+func DefaultPolicy() Policy {
+	return Policy{MaxAttempts: DefaultMaxAttempts, BaseDelay: DefaultBaseDelay, MaxDelay: DefaultMaxDelay}
+}
+
+// Transport는 methods에 포함된 요청만 재시도하는 RoundTripper이다.
+// 전체 소요 시간은 http.Client.Timeout(요청 context)으로 제한된다.
+type Transport struct {
+	base    http.RoundTripper
+	policy  Policy
+	methods map[string]struct{}
+
+	// 테스트에서 대기/난수를 바꾸기 위한 훅.
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func() float64
+}
+
+// NewTransport는 base를 재시도 Transport로 감싼다. methods가 비어 있으면 GET/HEAD만 재시도한다.
+// 재시도가 꺼진 정책(MaxAttempts<=1)이면 base를 그대로 반환한다.
+func NewTransport(base http.RoundTripper, policy Policy, methods ...string) http.RoundTripper {
+	if policy.MaxAttempts <= 1 {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultBaseDelay
+	}
+	if policy.MaxDelay < policy.BaseDelay {
+		policy.MaxDelay = policy.BaseDelay
+	}
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+	set := make(map[string]struct{}, len(methods))
+	for _, m := range methods {
+		set[m] = struct{}{}
+	}
+	return &Transport{base: base, policy: policy, methods: set, sleep: sleepContext, jitter: rand.Float64}
+}
+
+// Unwrap은 감싼 RoundTripper를 반환한다.
+func (t *Transport) Unwrap() http.RoundTripper {
+	return t.base
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := t.methods[req.Method]; !ok {
+		return t.base.RoundTrip(req)
+	}
+	// 본문을 다시 만들 수 없으면 재시도하지 않는다.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return t.base.RoundTrip(req)
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.policy.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		delay, retry := t.retryDelay(attempt, resp, err)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.CopyN(io.Discard, resp.Body, drainLimit)
+			resp.Body.Close()
+		}
+		if err := t.sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay는 재시도 여부와 대기 시간을 결정한다.
+// 연결 오류와 429/500/502/503/504 응답만 재시도한다.
+func (t *Transport) retryDelay(attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if err == nil && !retryableStatus(resp.StatusCode) {
+		return 0, false
+	}
+	delay := t.backoff(attempt)
+	if resp != nil {
+		if after := apierror.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); after > 0 {
+			if after > t.policy.MaxDelay {
+				// 서버가 요구한 대기 시간이 너무 길면 응답을 그대로 돌려 상위(requeue)에 맡긴다.
+				return 0, false
+			}
+			if after > delay {
+				delay = after
+			}
+		}
+	}
+	return delay, true
+}
+
+// backoff는 BaseDelay*2^(attempt-1)을 MaxDelay로 자른 뒤 [d/2, d) 범위의 jitter를 적용한다.
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := t.policy.BaseDelay
+	for i := 1; i < attempt && d < t.policy.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > t.policy.MaxDelay {
+		d = t.policy.MaxDelay
+	}
+	half := d / 2
+	return half + time.Duration(t.jitter()*float64(d-half))
+}
+
+func retryableStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+package httpretry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestTransport는 실제로 대기하지 않고 대기 시간만 기록하는 Transport를 만든다.
+func newTestTransport(t *testing.T, policy Policy, methods ...string) (*Transport, *[]time.Duration) {
+	t.Helper()
+	tr, ok := NewTransport(http.DefaultTransport, policy, methods...).(*Transport)
+	if !ok {
+		t.Fatalf("expected retrying transport")
+	}
+	var delays []time.Duration
+	tr.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	tr.jitter = func() float64 { return 1 }
+	return tr, &delays
+}
+
+func TestTransport_RetriesGetOnServerError(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	tr, delays := newTestTransport(t, Policy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second})
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("expected success on third attempt, got status=%d calls=%d", resp.StatusCode, calls)
+	}
+	// jitter=1이면 [d/2, d) 범위의 상한에 해당하는 d를 그대로 사용한다.
+	if len(*delays) != 2 || (*delays)[0] != 100*time.Millisecond || (*delays)[1] != 200*time.Millisecond {
+		t.Fatalf("unexpected backoff delays: %v", *delays)
+	}
+}
+
+func TestTransport_StopsAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	tr, _ := newTestTransport(t, Policy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadGateway || atomic.LoadInt32(&calls) != 2 {
+		t.Fatalf("expected last response after 2 attempts, got status=%d calls=%d", resp.StatusCode, calls)
+	}
+}
+
+func TestTransport_DoesNotRetry(t *testing.T) {
+	cases := []struct {
+		name    string
+		method  string
+		status  int
+		header  string
+		methods []string
+	}{
+		{name: "client error", method: http.MethodGet, status: http.StatusNotFound},
+		{name: "post not allowed", method: http.MethodPost, status: http.StatusServiceUnavailable},
+		{name: "retry-after too long", method: http.MethodGet, status: http.StatusTooManyRequests, header: "120"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				if tc.header != "" {
+					w.Header().Set("Retry-After", tc.header)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			tr, _ := newTestTransport(t, Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}, tc.methods...)
+			req, _ := http.NewRequest(tc.method, srv.URL, strings.NewReader("{}"))
+			resp, err := (&http.Client{Transport: tr}).Do(req)
+			if err != nil {
+				t.Fatalf("request error: %v", err)
+			}
+			resp.Body.Close()
+			if atomic.LoadInt32(&calls) != 1 {
+				t.Fatalf("expected a single attempt, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestTransport_RetryAfterAndBodyReplay(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"auth":{}}` {
+			t.Errorf("unexpected body on attempt %d: %q", calls+1, body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	tr, delays := newTestTransport(t, Policy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 5 * time.Second}, http.MethodPost)
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"auth":{}}`))
+	resp, err := (&http.Client{Transport: tr}).Do(req)
+	if err != nil {
+		t.Fatalf("Do error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated || len(*delays) != 1 || (*delays)[0] != 2*time.Second {
+		t.Fatalf("expected one retry after Retry-After, got status=%d delays=%v", resp.StatusCode, *delays)
+	}
+}
+
+func TestTransport_RetriesConnectionErrorAndHonorsContext(t *testing.T) {
+	var calls int32
+	failing := roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errors.New("connection reset by peer")
+	})
+	tr := NewTransport(failing, Policy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}).(*Transport)
+	req, _ := http.NewRequest(http.MethodGet, "http://example.invalid", http.NoBody)
+	if _, err := tr.RoundTrip(req); err == nil || atomic.LoadInt32(&calls) != 3 {
+		t.Fatalf("expected 3 attempts ending in error, got calls=%d err=%v", calls, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	atomic.StoreInt32(&calls, 0)
+	if _, err := tr.RoundTrip(req.WithContext(ctx)); err == nil || atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("expected cancelled context to stop retries, got calls=%d err=%v", calls, err)
+	}
+}
+
+func TestNewTransport_DisabledPolicy(t *testing.T) {
+	if _, ok := NewTransport(http.DefaultTransport, Policy{MaxAttempts: 1}).(*Transport); ok {
+		t.Fatalf("expected base transport when retries are disabled")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
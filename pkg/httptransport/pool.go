@@ -0,0 +1,180 @@
+package httptransport
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// DefaultIdleTTL은 사용되지 않은 공유 transport를 레지스트리에서 제거하기까지의 시간이다.
+// CA bundle/인증서 교체로 키가 바뀐 이전 transport가 계속 남지 않도록 한다.
+const DefaultIdleTTL = 10 * time.Minute
+
+// 연결 풀 기본값.
+const (
+	MaxIdleConns        = 100
+	MaxIdleConnsPerHost = 10
+	IdleConnTimeout     = 90 * time.Second
+)
+
+// Key는 공유 transport를 구분한다. 같은 Key의 클라이언트는 연결 풀을 공유한다.
+type Key struct {
+	// Service는 대상 API 이름이다. (contrabass, openstack, viola; 메트릭 라벨로 사용)
+	Service string
+	// TLS는 CA bundle/클라이언트 인증서 내용의 지문이다. 비어 있으면 시스템 CA만 사용한다.
+	TLS string
+	// Insecure는 서버 인증서 검증 생략 여부이다.
+	Insecure bool
+	// Timeout은 응답 헤더 대기 시간이다. (0이면 제한 없음, 요청 전체는 http.Client.Timeout으로 제한)
+	Timeout time.Duration
+}
+
+// Observer는 연결 풀 상태 변화를 전달받는다.
+type Observer interface {
+	// ConnOpened는 새 연결이 맺어질 때 호출된다.
+	ConnOpened(service string)
+	// ConnClosed는 연결이 닫힐 때 호출된다.
+	ConnClosed(service string)
+	// ConnAcquired는 요청이 연결을 얻을 때 호출된다. reused는 유휴 연결 재사용 여부이다.
+	ConnAcquired(service string, reused bool)
+	// PoolsChanged는 레지스트리의 transport 수가 바뀔 때 호출된다.
+	PoolsChanged(count int)
+}
+
+type pooled struct {
+	rt       http.RoundTripper
+	tr       *http.Transport
+	lastUsed time.Time
+}
+
+// Registry는 Key별 http.Transport를 재사용해 reconcile 간 연결/TLS 세션을 공유한다.
+type Registry struct {
+	mu         sync.Mutex
+	transports map[Key]*pooled
+	observer   Observer
+	idleTTL    time.Duration
+	now        func() time.Time
+}
+
+// NewRegistry는 observer(nil 가능)를 사용하는 레지스트리를 만든다.
+func NewRegistry(observer Observer) *Registry {
+	return &Registry{
+		transports: make(map[Key]*pooled),
+		observer:   observer,
+		idleTTL:    DefaultIdleTTL,
+		now:        time.Now,
+	}
+}
+
+// Get은 key에 해당하는 공유 RoundTripper를 반환한다. 없으면 tlsConfig로 새로 만든다.
+// tlsConfig는 key.TLS와 같은 내용이어야 한다. (생성 시에만 사용)
+func (r *Registry) Get(key Key, tlsConfig *tls.Config) http.RoundTripper {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	p, ok := r.transports[key]
+	if !ok {
+		tr := r.newTransport(key, tlsConfig)
+		p = &pooled{rt: &tracingTransport{base: tr, service: key.Service, observer: r.observer}, tr: tr}
+		r.transports[key] = p
+	}
+	p.lastUsed = now
+	removed := r.evictIdleLocked(now)
+	if (!ok || removed > 0) && r.observer != nil {
+		r.observer.PoolsChanged(len(r.transports))
+	}
+	return p.rt
+}
+
+// Len은 레지스트리의 transport 수를 반환한다.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.transports)
+}
+
+// CloseIdleConnections는 모든 transport의 유휴 연결을 닫는다.
+func (r *Registry) CloseIdleConnections() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, p := range r.transports {
+		p.tr.CloseIdleConnections()
+	}
+}
+
+// evictIdleLocked는 idleTTL 동안 사용되지 않은 transport를 제거하고 제거 수를 반환한다.
+func (r *Registry) evictIdleLocked(now time.Time) int {
+	removed := 0
+	for key, p := range r.transports {
+		if now.Sub(p.lastUsed) <= r.idleTTL {
+			continue
+		}
+		p.tr.CloseIdleConnections()
+		delete(r.transports, key)
+		removed++
+	}
+	return removed
+}
+
+func (r *Registry) newTransport(key Key, tlsConfig *tls.Config) *http.Transport {
+	tr := New(tlsConfig, key.Insecure)
+	tr.MaxIdleConns = MaxIdleConns
+	tr.MaxIdleConnsPerHost = MaxIdleConnsPerHost
+	tr.IdleConnTimeout = IdleConnTimeout
+	tr.ResponseHeaderTimeout = key.Timeout
+	if r.observer != nil {
+		dial := tr.DialContext
+		service := key.Service
+		observer := r.observer
+		tr.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dial(ctx, network, addr)
+			if err != nil {
+				return nil, err
+			}
+			observer.ConnOpened(service)
+			return &observedConn{Conn: conn, onClose: func() { observer.ConnClosed(service) }}, nil
+		}
+	}
+	return tr
+}
+
+// tracingTransport는 요청마다 연결 획득(신규/재사용)을 observer에 전달한다.
+type tracingTransport struct {
+	base     http.RoundTripper
+	service  string
+	observer Observer
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.observer == nil {
+		return t.base.RoundTrip(req)
+	}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.observer.ConnAcquired(t.service, info.Reused)
+		},
+	}
+	return t.base.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+}
+
+// Unwrap은 감싼 http.Transport를 반환한다.
+func (t *tracingTransport) Unwrap() http.RoundTripper {
+	return t.base
+}
+
+// observedConn은 Close를 한 번만 observer에 알린다.
+type observedConn struct {
+	net.Conn
+	once    sync.Once
+	onClose func()
+}
+
+func (c *observedConn) Close() error {
+	err := c.Conn.Close()
+	c.once.Do(c.onClose)
+	return err
+}
@@ -0,0 +1,117 @@
+package httptransport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingObserver struct {
+	mu       sync.Mutex
+	open     int
+	opened   int
+	reused   int
+	fresh    int
+	poolSize int
+}
+
+func (o *countingObserver) ConnOpened(string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.open++
+	o.opened++
+}
+
+func (o *countingObserver) ConnClosed(string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.open--
+}
+
+func (o *countingObserver) ConnAcquired(_ string, reused bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if reused {
+		o.reused++
+	} else {
+		o.fresh++
+	}
+}
+
+func (o *countingObserver) PoolsChanged(count int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.poolSize = count
+}
+
+func TestRegistry_SharesConnectionsAcrossClients(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer srv.Close()
+
+	obs := &countingObserver{}
+	reg := NewRegistry(obs)
+	key := Key{Service: "viola", Timeout: 5 * time.Second}
+	for i := 0; i < 3; i++ {
+		// reconcile마다 새 http.Client를 만들어도 같은 transport를 쓰면 연결을 재사용한다.
+		client := &http.Client{Transport: reg.Get(key, nil)}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			t.Fatalf("request %d: %v", i, err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	obs.mu.Lock()
+	opened, fresh, reused, pools := obs.opened, obs.fresh, obs.reused, obs.poolSize
+	obs.mu.Unlock()
+	if opened != 1 || fresh != 1 || reused != 2 {
+		t.Fatalf("expected 1 dial and 2 reuses, got opened=%d fresh=%d reused=%d", opened, fresh, reused)
+	}
+	if pools != 1 || reg.Len() != 1 {
+		t.Fatalf("expected a single pooled transport, got observer=%d len=%d", pools, reg.Len())
+	}
+
+	reg.CloseIdleConnections()
+	obs.mu.Lock()
+	open := obs.open
+	obs.mu.Unlock()
+	if open != 0 {
+		t.Fatalf("expected idle connection to be closed, got %d open", open)
+	}
+}
+
+func TestRegistry_KeysAndEviction(t *testing.T) {
+	obs := &countingObserver{}
+	reg := NewRegistry(obs)
+	now := time.Unix(0, 0)
+	reg.now = func() time.Time { return now }
+
+	a := reg.Get(Key{Service: "openstack"}, nil)
+	if reg.Get(Key{Service: "openstack"}, nil) != a {
+		t.Fatalf("expected same transport for same key")
+	}
+	if reg.Get(Key{Service: "openstack", TLS: "rotated"}, nil) == a {
+		t.Fatalf("expected new transport for different TLS fingerprint")
+	}
+	if reg.Len() != 2 {
+		t.Fatalf("expected 2 transports, got %d", reg.Len())
+	}
+
+	// 인증서 교체 후 이전 키는 더 이상 사용되지 않으므로 idleTTL 후 제거된다.
+	now = now.Add(DefaultIdleTTL / 2)
+	reg.Get(Key{Service: "openstack", TLS: "rotated"}, nil)
+	now = now.Add(DefaultIdleTTL/2 + time.Second)
+	reg.Get(Key{Service: "openstack", TLS: "rotated"}, nil)
+	if reg.Len() != 1 {
+		t.Fatalf("expected unused transport to be evicted, got %d", reg.Len())
+	}
+	if obs.poolSize != 1 {
+		t.Fatalf("expected observer to see 1 pool, got %d", obs.poolSize)
+	}
+}
@@ -0,0 +1,40 @@
+// Package httptransport는 API 클라이언트가 공통으로 사용하는 http.Transport를 만든다.
+package httptransport
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// 연결 기본값.
+const (
+	DialTimeout = 5 * time.Second
+	KeepAlive   = 30 * time.Second
+)
+
+// New는 tlsConfig와 insecure를 적용한 http.Transport를 만든다.
+// tlsConfig가 nil이면 시스템 CA로 서버 인증서를 검증한다.
+func New(tlsConfig *tls.Config, insecure bool) *http.Transport {
+	return &http.Transport{
+		TLSClientConfig: TLSConfig(tlsConfig, insecure),
+		DialContext: (&net.Dialer{
+			Timeout:   DialTimeout,
+			KeepAlive: KeepAlive,
+		}).DialContext,
+		ForceAttemptHTTP2: true,
+	}
+}
+
+// TLSConfig는 base를 복제해 insecure(InsecureSkipVerify)를 적용한다. base는 변경하지 않는다.
+func TLSConfig(base *tls.Config, insecure bool) *tls.Config {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if base != nil {
+		cfg = base.Clone()
+	}
+	if insecure {
+		cfg.InsecureSkipVerify = true //nolint:gosec
+	}
+	return cfg
+}
@@ -0,0 +1,207 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DefaultExchanges는 구독할 OpenStack 서비스 exchange 목록이다. (oslo control_exchange 기본값)
+var DefaultExchanges = []string{"neutron", "nova"}
+
+// DefaultTopics는 구독할 알림 topic 목록이다. (legacy/versioned Nova 알림 모두 수신)
+var DefaultTopics = []string{"notifications", "versioned_notifications"}
+
+// Config는 RabbitMQ 접속 및 구독 설정이다.
+type Config struct {
+	// URLs는 Contrabass provider의 rabbitMQUrls이며, 앞에서부터 순서대로 접속을 시도한다.
+	URLs      []string
+	User      string
+	Password  string
+	Exchanges []string
+	Topics    []string
+}
+
+// Broker는 알림 메시지를 전달하는 메시지 브로커 연결이다.
+type Broker interface {
+	// Consume은 ctx가 취소되거나 연결이 끊길 때까지 메시지 본문을 handle로 전달한다.
+	Consume(ctx context.Context, handle func(body []byte)) error
+}
+
+// BrokerFactory는 Config로 Broker를 만든다. (테스트에서는 MemoryBroker로 대체)
+type BrokerFactory func(cfg Config) Broker
+
+// NewAMQPBroker는 RabbitMQ(AMQP 0-9-1) Broker를 만든다.
+func NewAMQPBroker(cfg Config) Broker {
+	return &amqpBroker{cfg: cfg}
+}
+
+type amqpBroker struct {
+	cfg Config
+}
+
+func (b *amqpBroker) Consume(ctx context.Context, handle func(body []byte)) error {
+	conn, err := b.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("notification: open channel: %w", err)
+	}
+	defer ch.Close()
+
+	// 연결이 끊기면 알림을 놓쳐도 되므로(폴링으로 보완) 임시 큐를 사용한다.
+	q, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return fmt.Errorf("notification: declare queue: %w", err)
+	}
+	for _, exchange := range orDefault(b.cfg.Exchanges, DefaultExchanges) {
+		for _, topic := range orDefault(b.cfg.Topics, DefaultTopics) {
+			if err := ch.QueueBind(q.Name, topic+".info", exchange, false, nil); err != nil {
+				return fmt.Errorf("notification: bind %s/%s.info: %w", exchange, topic, err)
+			}
+		}
+	}
+	deliveries, err := ch.ConsumeWithContext(ctx, q.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("notification: consume: %w", err)
+	}
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case amqpErr := <-closed:
+			if amqpErr != nil {
+				return fmt.Errorf("notification: connection closed: %w", amqpErr)
+			}
+			return errors.New("notification: connection closed")
+		case d, ok := <-deliveries:
+			if !ok {
+				return errors.New("notification: delivery channel closed")
+			}
+			handle(d.Body)
+		}
+	}
+}
+
+func (b *amqpBroker) dial() (*amqp.Connection, error) {
+	if len(b.cfg.URLs) == 0 {
+		return nil, errors.New("notification: no rabbitmq urls")
+	}
+	var errs []error
+	for _, raw := range b.cfg.URLs {
+		addr, err := amqpURL(raw, b.cfg.User, b.cfg.Password)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		conn, err := amqp.Dial(addr)
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, fmt.Errorf("notification: dial %s: %w", redact(raw), err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// amqpURL은 host:port 형태도 허용하고, 자격증명을 URL에 넣는다.
+func amqpURL(raw, user, password string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "amqp://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", fmt.Errorf("notification: invalid rabbitmq url %q: %w", redact(raw), err)
+	}
+	if user != "" {
+		u.User = url.UserPassword(user, password)
+	}
+	return u.String(), nil
+}
+
+// redact는 로그/오류에 자격증명이 남지 않도록 userinfo를 제거한다.
+func redact(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.User == nil {
+		return raw
+	}
+	u.User = nil
+	return u.String()
+}
+
+func orDefault(values, def []string) []string {
+	if len(values) == 0 {
+		return def
+	}
+	return values
+}
+
+// MemoryBroker는 RabbitMQ 없이 Publish한 메시지를 전달하는 로컬 Broker이다. (테스트/데모용)
+type MemoryBroker struct {
+	mu        sync.Mutex
+	consumers map[chan []byte]chan struct{}
+}
+
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{consumers: make(map[chan []byte]chan struct{})}
+}
+
+// Factory는 어떤 Config에도 이 MemoryBroker를 반환하는 BrokerFactory이다.
+func (b *MemoryBroker) Factory() BrokerFactory {
+	return func(Config) Broker { return b }
+}
+
+// Publish는 현재 구독 중인 모든 Consume에 메시지를 전달한다.
+func (b *MemoryBroker) Publish(body []byte) {
+	b.mu.Lock()
+	consumers := make(map[chan []byte]chan struct{}, len(b.consumers))
+	for ch, done := range b.consumers {
+		consumers[ch] = done
+	}
+	b.mu.Unlock()
+	for ch, done := range consumers {
+		select {
+		case ch <- body:
+		case <-done:
+		}
+	}
+}
+
+// Consumers는 현재 구독 중인 Consume 수를 반환한다.
+func (b *MemoryBroker) Consumers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.consumers)
+}
+
+func (b *MemoryBroker) Consume(ctx context.Context, handle func(body []byte)) error {
+	ch := make(chan []byte, 64)
+	done := make(chan struct{})
+	b.mu.Lock()
+	b.consumers[ch] = done
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.consumers, ch)
+		b.mu.Unlock()
+		close(done)
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case body := <-ch:
+			handle(body)
+		}
+	}
+}
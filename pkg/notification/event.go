@@ -0,0 +1,127 @@
+package notification
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// 구독하는 OpenStack 알림 이벤트 타입
+const (
+	EventPortCreate      = "port.create.end"
+	EventPortUpdate      = "port.update.end"
+	EventPortDelete      = "port.delete.end"
+	EventInterfaceAttach = "instance.interface_attach.end"
+	EventInterfaceDetach = "instance.interface_detach.end"
+)
+
+// Event는 동기화 트리거에 필요한 알림 정보만 담는다.
+type Event struct {
+	Type      string
+	ProjectID string
+	VMID      string
+	PortID    string
+}
+
+// envelope는 oslo.messaging 2.0 메시지 포맷이다. (oslo.message에 JSON 문자열이 들어 있음)
+type envelope struct {
+	Version string `json:"oslo.version"`
+	Message string `json:"oslo.message"`
+}
+
+type message struct {
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+	ProjectID string          `json:"_context_project_id"`
+	TenantID  string          `json:"_context_tenant"`
+}
+
+type portBody struct {
+	ID        string `json:"id"`
+	DeviceID  string `json:"device_id"`
+	ProjectID string `json:"project_id"`
+	TenantID  string `json:"tenant_id"`
+}
+
+type neutronPayload struct {
+	Port   *portBody `json:"port"`
+	PortID string    `json:"port_id"`
+}
+
+type instanceBody struct {
+	UUID       string `json:"uuid"`
+	InstanceID string `json:"instance_id"`
+	TenantID   string `json:"tenant_id"`
+	ProjectID  string `json:"project_id"`
+}
+
+// novaPayload는 versioned(nova_object.data)와 legacy(payload 최상위) 포맷을 모두 받는다.
+type novaPayload struct {
+	instanceBody
+	Data *instanceBody `json:"nova_object.data"`
+}
+
+// Parse는 알림 메시지를 해석한다. 구독 대상이 아니거나 해석할 수 없으면 false를 반환한다.
+func Parse(body []byte) (Event, bool) {
+	var env envelope
+	if err := json.Unmarshal(body, &env); err == nil && env.Message != "" {
+		body = []byte(env.Message)
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return Event{}, false
+	}
+	ev := Event{Type: normalizeType(msg.EventType), ProjectID: firstNonEmpty(msg.ProjectID, msg.TenantID)}
+	switch ev.Type {
+	case EventPortCreate, EventPortUpdate, EventPortDelete:
+		var p neutronPayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return Event{}, false
+		}
+		ev.PortID = p.PortID
+		if p.Port != nil {
+			ev.PortID = firstNonEmpty(p.Port.ID, ev.PortID)
+			ev.VMID = p.Port.DeviceID
+			ev.ProjectID = firstNonEmpty(p.Port.ProjectID, p.Port.TenantID, ev.ProjectID)
+		}
+	case EventInterfaceAttach, EventInterfaceDetach:
+		var p novaPayload
+		if err := json.Unmarshal(msg.Payload, &p); err != nil {
+			return Event{}, false
+		}
+		inst := p.instanceBody
+		if p.Data != nil {
+			inst = *p.Data
+		}
+		ev.VMID = firstNonEmpty(inst.UUID, inst.InstanceID)
+		ev.ProjectID = firstNonEmpty(inst.ProjectID, inst.TenantID, ev.ProjectID)
+	default:
+		return Event{}, false
+	}
+	if ev.VMID == "" && ev.PortID == "" {
+		return Event{}, false
+	}
+	return ev, true
+}
+
+// legacyTypes는 legacy(unversioned) Nova 이벤트 이름을 versioned 이름으로 맞춘다.
+var legacyTypes = map[string]string{
+	"compute.instance.interface_attach": EventInterfaceAttach,
+	"compute.instance.interface_detach": EventInterfaceDetach,
+}
+
+func normalizeType(eventType string) string {
+	eventType = strings.TrimSpace(eventType)
+	if mapped, ok := legacyTypes[eventType]; ok {
+		return mapped
+	}
+	return eventType
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
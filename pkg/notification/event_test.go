@@ -0,0 +1,83 @@
+package notification
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func osloEnvelope(t *testing.T, msg map[string]any) []byte {
+	t.Helper()
+	inner, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("marshal message: %v", err)
+	}
+	body, err := json.Marshal(map[string]string{"oslo.version": "2.0", "oslo.message": string(inner)})
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	return body
+}
+
+func TestParse_NeutronPortEvents(t *testing.T) {
+	body := osloEnvelope(t, map[string]any{
+		"event_type": "port.create.end",
+		"payload": map[string]any{"port": map[string]any{
+			"id": "port-1", "device_id": "vm-1", "project_id": "project-1",
+		}},
+	})
+	ev, ok := Parse(body)
+	if !ok {
+		t.Fatalf("expected port.create.end to be parsed")
+	}
+	if ev.Type != EventPortCreate || ev.PortID != "port-1" || ev.VMID != "vm-1" || ev.ProjectID != "project-1" {
+		t.Fatalf("unexpected event: %+v", ev)
+	}
+
+	// port.delete.end는 port_id만 들어 있을 수 있다.
+	body = osloEnvelope(t, map[string]any{
+		"event_type":          "port.delete.end",
+		"_context_project_id": "project-1",
+		"payload":             map[string]any{"port_id": "port-2"},
+	})
+	ev, ok = Parse(body)
+	if !ok || ev.PortID != "port-2" || ev.VMID != "" || ev.ProjectID != "project-1" {
+		t.Fatalf("unexpected delete event: %+v (ok=%v)", ev, ok)
+	}
+}
+
+func TestParse_NovaInterfaceEvents(t *testing.T) {
+	versioned, _ := json.Marshal(map[string]any{
+		"event_type": "instance.interface_attach.end",
+		"payload": map[string]any{
+			"nova_object.name": "InstanceActionPayload",
+			"nova_object.data": map[string]any{"uuid": "vm-1", "tenant_id": "project-1"},
+		},
+	})
+	ev, ok := Parse(versioned)
+	if !ok || ev.Type != EventInterfaceAttach || ev.VMID != "vm-1" || ev.ProjectID != "project-1" {
+		t.Fatalf("unexpected versioned event: %+v (ok=%v)", ev, ok)
+	}
+
+	legacy := osloEnvelope(t, map[string]any{
+		"event_type": "compute.instance.interface_detach",
+		"payload":    map[string]any{"instance_id": "vm-2", "tenant_id": "project-1"},
+	})
+	ev, ok = Parse(legacy)
+	if !ok || ev.Type != EventInterfaceDetach || ev.VMID != "vm-2" {
+		t.Fatalf("unexpected legacy event: %+v (ok=%v)", ev, ok)
+	}
+}
+
+func TestParse_IgnoresOtherEvents(t *testing.T) {
+	cases := [][]byte{
+		osloEnvelope(t, map[string]any{"event_type": "port.create.start", "payload": map[string]any{"port": map[string]any{"id": "p"}}}),
+		osloEnvelope(t, map[string]any{"event_type": "instance.create.end", "payload": map[string]any{}}),
+		osloEnvelope(t, map[string]any{"event_type": "port.update.end", "payload": map[string]any{}}),
+		[]byte("not json"),
+	}
+	for i, body := range cases {
+		if ev, ok := Parse(body); ok {
+			t.Fatalf("case %d: expected event to be ignored, got %+v", i, ev)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package notification
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	minReconnectBackoff = time.Second
+	maxReconnectBackoff = time.Minute
+)
+
+// Handler는 provider별로 수신한 알림 이벤트를 처리한다.
+type Handler func(providerID string, ev Event)
+
+type ListenerOption func(*Listener)
+
+// WithBrokerFactory는 Broker 생성 방식을 바꾼다. (기본: NewAMQPBroker)
+func WithBrokerFactory(factory BrokerFactory) ListenerOption {
+	return func(l *Listener) { l.newBroker = factory }
+}
+
+// WithExchanges는 구독할 exchange 목록을 바꾼다. (기본: DefaultExchanges)
+func WithExchanges(exchanges []string) ListenerOption {
+	return func(l *Listener) { l.exchanges = exchanges }
+}
+
+// WithTopics는 구독할 topic 목록을 바꾼다. (기본: DefaultTopics)
+func WithTopics(topics []string) ListenerOption {
+	return func(l *Listener) { l.topics = topics }
+}
+
+// Listener는 provider(RabbitMQ)별로 하나의 구독을 유지하며 수신한 이벤트를 Handler로 전달한다.
+// 구독은 이를 사용하는 owner(OpenstackConfig)가 하나도 없으면 종료된다.
+type Listener struct {
+	log       logr.Logger
+	newBroker BrokerFactory
+	exchanges []string
+	topics    []string
+
+	mu      sync.Mutex
+	ctx     context.Context
+	handler Handler
+	subs    map[string]*subscription
+}
+
+type subscription struct {
+	cfg    Config
+	owners map[string]struct{}
+	cancel context.CancelFunc
+}
+
+func NewListener(log logr.Logger, opts ...ListenerOption) *Listener {
+	l := &Listener{
+		log:       log,
+		newBroker: NewAMQPBroker,
+		subs:      make(map[string]*subscription),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// SetHandler는 이벤트 처리 함수를 등록한다. Start 전에 호출해야 한다.
+func (l *Listener) SetHandler(h Handler) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.handler = h
+}
+
+// Start는 manager.Runnable 구현이다. Start 이전에 Ensure된 구독도 이때 시작한다.
+func (l *Listener) Start(ctx context.Context) error {
+	l.mu.Lock()
+	l.ctx = ctx
+	for providerID, sub := range l.subs {
+		l.startLocked(providerID, sub)
+	}
+	l.mu.Unlock()
+
+	<-ctx.Done()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, sub := range l.subs {
+		if sub.cancel != nil {
+			sub.cancel()
+			sub.cancel = nil
+		}
+	}
+	l.ctx = nil
+	return nil
+}
+
+// Ensure는 owner가 providerID의 알림을 구독하도록 등록한다.
+// 접속 정보(URL/자격증명)가 바뀌면 구독을 재시작한다.
+func (l *Listener) Ensure(owner, providerID string, cfg Config) {
+	cfg.Exchanges = orDefault(l.exchanges, DefaultExchanges)
+	cfg.Topics = orDefault(l.topics, DefaultTopics)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// owner가 다른 provider로 옮겨간 경우 이전 구독에서 뺀다.
+	for id := range l.subs {
+		if id != providerID {
+			l.releaseLocked(owner, id)
+		}
+	}
+	sub, ok := l.subs[providerID]
+	if !ok {
+		sub = &subscription{owners: make(map[string]struct{})}
+		l.subs[providerID] = sub
+	}
+	sub.owners[owner] = struct{}{}
+	if ok && reflect.DeepEqual(sub.cfg, cfg) {
+		return
+	}
+	sub.cfg = cfg
+	if sub.cancel != nil {
+		sub.cancel()
+		sub.cancel = nil
+	}
+	l.startLocked(providerID, sub)
+}
+
+// Release는 owner의 구독 등록을 해제한다. (CR 삭제 또는 RabbitMQ 정보가 없을 때)
+func (l *Listener) Release(owner string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for id := range l.subs {
+		l.releaseLocked(owner, id)
+	}
+}
+
+// Subscriptions는 현재 등록된 provider 수를 반환한다.
+func (l *Listener) Subscriptions() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.subs)
+}
+
+func (l *Listener) releaseLocked(owner, providerID string) {
+	sub := l.subs[providerID]
+	delete(sub.owners, owner)
+	if len(sub.owners) > 0 {
+		return
+	}
+	if sub.cancel != nil {
+		sub.cancel()
+	}
+	delete(l.subs, providerID)
+}
+
+func (l *Listener) startLocked(providerID string, sub *subscription) {
+	if l.ctx == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(l.ctx)
+	sub.cancel = cancel
+	go l.run(ctx, providerID, l.newBroker(sub.cfg))
+}
+
+// run은 ctx가 취소될 때까지 구독을 유지하며, 연결이 끊기면 지수 백오프로 재접속한다.
+func (l *Listener) run(ctx context.Context, providerID string, broker Broker) {
+	log := l.log.WithValues("providerID", providerID)
+	handle := func(body []byte) {
+		ev, ok := Parse(body)
+		if !ok {
+			return
+		}
+		l.mu.Lock()
+		h := l.handler
+		l.mu.Unlock()
+		if h != nil {
+			h(providerID, ev)
+		}
+	}
+
+	backoff := minReconnectBackoff
+	for {
+		started := time.Now()
+		err := broker.Consume(ctx, handle)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) > maxReconnectBackoff {
+			backoff = minReconnectBackoff
+		}
+		log.Error(err, "notification subscription failed; reconnecting", "after", backoff)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxReconnectBackoff)
+	}
+}
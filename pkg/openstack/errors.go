@@ -0,0 +1,15 @@
+package openstack
+
+import (
+	"net/http"
+
+	"multinic-operator/pkg/apierror"
+)
+
+// ErrUnauthorized는 토큰이 만료/폐기되어 401을 받은 경우를 나타낸다.
+var ErrUnauthorized = apierror.ErrUnauthorized
+
+// unexpectedStatus는 예상하지 못한 응답을 *apierror.Error로 만든다. 401은 errors.Is(err, ErrUnauthorized)를 만족한다.
+func unexpectedStatus(service, operation string, resp *http.Response) error {
+	return apierror.FromResponse(service, operation, resp)
+}
@@ -6,32 +6,45 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net"
 	"net/http"
 	"strings"
 	"time"
+
+	"multinic-operator/pkg/httpretry"
+	"multinic-operator/pkg/httptransport"
 )
 
 type KeystoneClient struct {
 	baseURL    string
 	domain     string
 	httpClient *http.Client
+	retry      httpretry.Policy
+
+	tlsConfig   *tls.Config
+	insecureTLS bool
+	transport   http.RoundTripper
 }
 
 type KeystoneOption func(*KeystoneClient)
 
 func WithKeystoneInsecureTLS(insecure bool) KeystoneOption {
-	return func(c *KeystoneClient) {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure}, //nolint:gosec
-			DialContext: (&net.Dialer{
-				Timeout:   5 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			ForceAttemptHTTP2: true,
-		}
-		c.httpClient.Transport = tr
-	}
+	return func(c *KeystoneClient) { c.insecureTLS = insecure }
+}
+
+// WithKeystoneTLSConfig는 CA bundle/클라이언트 인증서를 담은 TLS 설정을 지정한다.
+func WithKeystoneTLSConfig(cfg *tls.Config) KeystoneOption {
+	return func(c *KeystoneClient) { c.tlsConfig = cfg }
+}
+
+// WithKeystoneTransport는 공유 transport를 사용하게 한다. 지정하면 TLS 관련 옵션은 무시된다.
+func WithKeystoneTransport(rt http.RoundTripper) KeystoneOption {
+	return func(c *KeystoneClient) { c.transport = rt }
+}
+
+// WithKeystoneRetry는 요청 재시도 정책을 지정한다.
+// 토큰 발급(POST /auth/tokens)은 부수효과가 없으므로 GET과 함께 재시도한다.
+func WithKeystoneRetry(policy httpretry.Policy) KeystoneOption {
+	return func(c *KeystoneClient) { c.retry = policy }
 }
 
 func NewKeystoneClient(baseURL, domain string, timeout time.Duration, opts ...KeystoneOption) *KeystoneClient {
@@ -44,14 +57,16 @@ func NewKeystoneClient(baseURL, domain string, timeout time.Duration, opts ...Ke
 		domain:  domain,
 		httpClient: &http.Client{
 			Timeout: timeout,
-			Transport: &http.Transport{
-				ForceAttemptHTTP2: true,
-			},
 		},
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	rt := c.transport
+	if rt == nil {
+		rt = httptransport.New(c.tlsConfig, c.insecureTLS)
+	}
+	c.httpClient.Transport = httpretry.NewTransport(rt, c.retry, http.MethodGet, http.MethodPost)
 	return c
 }
 
@@ -96,7 +111,15 @@ type tokenResponse struct {
 }
 
 type tokenBody struct {
-	Catalog []catalogEntry `json:"catalog"`
+	ExpiresAt string         `json:"expires_at"`
+	Catalog   []catalogEntry `json:"catalog"`
+}
+
+// Token은 발급된 Keystone 토큰과 만료 시각, 서비스 카탈로그를 담는다.
+type Token struct {
+	ID        string
+	ExpiresAt time.Time
+	Catalog   []catalogEntry
 }
 
 type catalogEntry struct {
@@ -145,7 +168,7 @@ func (c *KeystoneClient) AuthToken(ctx context.Context, username, password, proj
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("keystone: unexpected status %d", resp.StatusCode)
+		return "", unexpectedStatus("keystone", "issue token", resp)
 	}
 
 	token := resp.Header.Get("X-Subject-Token")
@@ -158,6 +181,16 @@ func (c *KeystoneClient) AuthToken(ctx context.Context, username, password, proj
 // AuthTokenWithCatalog returns X-Subject-Token and service catalog using password grant.
 // Keystone 토큰과 서비스 카탈로그를 함께 가져온다.
 func (c *KeystoneClient) AuthTokenWithCatalog(ctx context.Context, username, password, projectID string) (string, []catalogEntry, error) {
+	token, err := c.IssueToken(ctx, username, password, projectID)
+	if err != nil {
+		return "", nil, err
+	}
+	return token.ID, token.Catalog, nil
+}
+
+// IssueToken returns a token with its expiry and service catalog using password grant.
+// expires_at이 없거나 해석할 수 없으면 ExpiresAt은 zero 값이다.
+func (c *KeystoneClient) IssueToken(ctx context.Context, username, password, projectID string) (Token, error) {
 	body := authRequest{
 		Auth: authIdentity{
 			Identity: identityContent{
@@ -176,30 +209,34 @@ func (c *KeystoneClient) AuthTokenWithCatalog(ctx context.Context, username, pas
 	payload, _ := json.Marshal(body)
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/auth/tokens", c.baseURL), bytes.NewReader(payload))
 	if err != nil {
-		return "", nil, err
+		return Token{}, err
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return "", nil, err
+		return Token{}, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", nil, fmt.Errorf("keystone: unexpected status %d", resp.StatusCode)
+		return Token{}, unexpectedStatus("keystone", "issue token", resp)
 	}
 
-	token := resp.Header.Get("X-Subject-Token")
-	if token == "" {
-		return "", nil, fmt.Errorf("keystone: missing X-Subject-Token")
+	id := resp.Header.Get("X-Subject-Token")
+	if id == "" {
+		return Token{}, fmt.Errorf("keystone: missing X-Subject-Token")
 	}
 
 	var out tokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return "", nil, err
+		return Token{}, err
+	}
+	token := Token{ID: id, Catalog: out.Token.Catalog}
+	if expiresAt, err := time.Parse(time.RFC3339Nano, out.Token.ExpiresAt); err == nil {
+		token.ExpiresAt = expiresAt
 	}
-	return token, out.Token.Catalog, nil
+	return token, nil
 }
 
 // FindEndpoint returns a normalized endpoint URL from the service catalog.
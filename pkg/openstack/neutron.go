@@ -5,47 +5,79 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
+
+	"multinic-operator/pkg/httpretry"
+	"multinic-operator/pkg/httptransport"
 )
 
 type NeutronClient struct {
 	baseURL    string
+	pageSize   int
 	httpClient *http.Client
+	retry      httpretry.Policy
+
+	tlsConfig   *tls.Config
+	insecureTLS bool
+	transport   http.RoundTripper
 }
 
+// defaultNeutronPageSize는 목록 조회 시 한 페이지에 요청하는 항목 수(limit)이다.
+const defaultNeutronPageSize = 500
+
+// deviceIDChunkSize는 한 요청에 넣는 device_id 파라미터 수이다. (URL 길이 제한 방지)
+const deviceIDChunkSize = 50
+
 type NeutronOption func(*NeutronClient)
 
 func WithNeutronInsecureTLS(insecure bool) NeutronOption {
+	return func(c *NeutronClient) { c.insecureTLS = insecure }
+}
+
+// WithNeutronTLSConfig는 CA bundle/클라이언트 인증서를 담은 TLS 설정을 지정한다.
+func WithNeutronTLSConfig(cfg *tls.Config) NeutronOption {
+	return func(c *NeutronClient) { c.tlsConfig = cfg }
+}
+
+// WithNeutronTransport는 공유 transport를 사용하게 한다. 지정하면 TLS 관련 옵션은 무시된다.
+func WithNeutronTransport(rt http.RoundTripper) NeutronOption {
+	return func(c *NeutronClient) { c.transport = rt }
+}
+
+// WithNeutronPageSize는 목록 조회 limit을 지정한다. (0 이하이면 기본값)
+func WithNeutronPageSize(size int) NeutronOption {
 	return func(c *NeutronClient) {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure}, //nolint:gosec
-			DialContext: (&net.Dialer{
-				Timeout:   5 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			ForceAttemptHTTP2: true,
+		if size > 0 {
+			c.pageSize = size
 		}
-		c.httpClient.Transport = tr
 	}
 }
 
+// WithNeutronRetry는 조회(GET) 요청 재시도 정책을 지정한다.
+func WithNeutronRetry(policy httpretry.Policy) NeutronOption {
+	return func(c *NeutronClient) { c.retry = policy }
+}
+
 func NewNeutronClient(baseURL string, timeout time.Duration, opts ...NeutronOption) *NeutronClient {
 	c := &NeutronClient{
-		baseURL: baseURL,
+		baseURL:  baseURL,
+		pageSize: defaultNeutronPageSize,
 		httpClient: &http.Client{
 			Timeout: timeout,
-			Transport: &http.Transport{
-				ForceAttemptHTTP2: true,
-			},
 		},
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	rt := c.transport
+	if rt == nil {
+		rt = httptransport.New(c.tlsConfig, c.insecureTLS)
+	}
+	c.httpClient.Transport = httpretry.NewTransport(rt, c.retry)
 	return c
 }
 
@@ -65,10 +97,6 @@ type FixedIP struct {
 	SubnetID string `json:"subnet_id"`
 }
 
-type portsResponse struct {
-	Ports []Port `json:"ports"`
-}
-
 type Subnet struct {
 	ID        string `json:"id"`
 	Name      string `json:"name"`
@@ -77,10 +105,6 @@ type Subnet struct {
 	ProjectID string `json:"project_id"`
 }
 
-type subnetsResponse struct {
-	Subnets []Subnet `json:"subnets"`
-}
-
 type subnetResponse struct {
 	Subnet Subnet `json:"subnet"`
 }
@@ -95,58 +119,49 @@ type networkResponse struct {
 }
 
 // ListPorts fetches Neutron ports filtered by project and optional device IDs.
-// 프로젝트/VM 기준으로 포트 목록을 조회한다.
+// 프로젝트/VM 기준으로 포트 목록을 조회한다. device_id가 많으면 나눠서 요청하고, 모든 페이지를 가져온다.
 func (c *NeutronClient) ListPorts(ctx context.Context, token, projectID string, deviceIDs []string) ([]Port, error) {
 	q := url.Values{}
 	if projectID != "" {
 		q.Set("project_id", projectID)
 	}
-	if len(deviceIDs) > 0 {
-		// Neutron supports multiple device_id filters by repeating param
-		for _, id := range deviceIDs {
-			q.Add("device_id", id)
-		}
-	}
-	endpoint := c.baseURL + "/v2.0/ports"
-	if len(q) > 0 {
-		endpoint += "?" + q.Encode()
+	if len(deviceIDs) == 0 {
+		return listPaged(ctx, c, token, "ports", q, func(p Port) string { return p.ID })
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set("X-Auth-Token", token)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("neutron: unexpected status %d", resp.StatusCode)
+	set := make(map[string]struct{}, len(deviceIDs))
+	for _, id := range deviceIDs {
+		set[id] = struct{}{}
 	}
-	var out portsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return nil, err
-	}
-
-	// Optional: filter client-side if deviceIDs provided but API ignored duplicates
-	if len(deviceIDs) > 0 {
-		set := make(map[string]struct{}, len(deviceIDs))
-		for _, id := range deviceIDs {
-			set[id] = struct{}{}
+	seen := make(map[string]struct{})
+	var ports []Port
+	for start := 0; start < len(deviceIDs); start += deviceIDChunkSize {
+		end := min(start+deviceIDChunkSize, len(deviceIDs))
+		chunkQuery := url.Values{}
+		for k, v := range q {
+			chunkQuery[k] = v
+		}
+		// Neutron supports multiple device_id filters by repeating param
+		for _, id := range deviceIDs[start:end] {
+			chunkQuery.Add("device_id", id)
+		}
+		page, err := listPaged(ctx, c, token, "ports", chunkQuery, func(p Port) string { return p.ID })
+		if err != nil {
+			return nil, err
 		}
-		filtered := make([]Port, 0, len(out.Ports))
-		for _, p := range out.Ports {
-			if _, ok := set[p.DeviceID]; ok {
-				filtered = append(filtered, p)
+		// API가 device_id 필터를 무시하는 경우를 대비해 클라이언트에서도 거른다.
+		for _, p := range page {
+			if _, ok := set[p.DeviceID]; !ok {
+				continue
 			}
+			if _, dup := seen[p.ID]; dup {
+				continue
+			}
+			seen[p.ID] = struct{}{}
+			ports = append(ports, p)
 		}
-		return filtered, nil
 	}
-	return out.Ports, nil
+	return ports, nil
 }
 
 // ListSubnets fetches Neutron subnets filtered by project and optional name.
@@ -159,31 +174,111 @@ func (c *NeutronClient) ListSubnets(ctx context.Context, token, projectID, name
 	if strings.TrimSpace(name) != "" {
 		q.Set("name", strings.TrimSpace(name))
 	}
-	endpoint := c.baseURL + "/v2.0/subnets"
-	if len(q) > 0 {
-		endpoint += "?" + q.Encode()
+	return listPaged(ctx, c, token, "subnets", q, func(s Subnet) string { return s.ID })
+}
+
+// ListNetworks fetches Neutron networks filtered by project.
+// 프로젝트 기준으로 네트워크 목록을 조회한다.
+func (c *NeutronClient) ListNetworks(ctx context.Context, token, projectID string) ([]Network, error) {
+	q := url.Values{}
+	if projectID != "" {
+		q.Set("project_id", projectID)
+	}
+	return listPaged(ctx, c, token, "networks", q, func(n Network) string { return n.ID })
+}
+
+// listPaged는 limit/marker로 목록을 조회하며 <resource>_links의 next 링크를 따라 모든 페이지를 가져온다.
+// next 링크 없이 한 페이지가 정확히 limit만큼 차면 마지막 ID를 marker로 다음 페이지를 요청한다.
+// limit보다 많이 받거나 marker 요청에 새 항목이 없으면 pagination이 꺼진 것으로 보고 멈춘다.
+// next 링크로 받은 페이지에 새 항목이 없거나 최대 페이지 수를 넘으면 일부 목록 대신 오류를 반환한다.
+func listPaged[T any](ctx context.Context, c *NeutronClient, token, resource string, q url.Values, idOf func(T) string) ([]T, error) {
+	base := c.baseURL + "/v2.0/" + resource
+	query := url.Values{}
+	for k, v := range q {
+		query[k] = v
+	}
+	if c.pageSize > 0 {
+		query.Set("limit", strconv.Itoa(c.pageSize))
 	}
+	endpoint := base + "?" + query.Encode()
 
+	seen := make(map[string]struct{})
+	var items []T
+	// viaMarker는 현재 페이지를 next 링크가 아닌 marker로 요청했는지 여부이다.
+	viaMarker := false
+	for page := 0; endpoint != "" && page < maxListPages; page++ {
+		pageItems, links, err := c.getPage(ctx, token, endpoint, resource)
+		if err != nil {
+			return nil, err
+		}
+		var decoded []T
+		if err := json.Unmarshal(pageItems, &decoded); err != nil {
+			return nil, err
+		}
+		added := 0
+		for _, item := range decoded {
+			id := idOf(item)
+			if _, dup := seen[id]; dup {
+				continue
+			}
+			seen[id] = struct{}{}
+			items = append(items, item)
+			added++
+		}
+		if added == 0 && len(decoded) > 0 {
+			if viaMarker {
+				// pagination이 꺼진 서버는 marker를 무시하고 같은 목록을 다시 준다.
+				endpoint = ""
+				break
+			}
+			return nil, fmt.Errorf("neutron list %s: page %d returned no new items; pagination is not progressing", resource, page+1)
+		}
+		endpoint = nextLink(links)
+		viaMarker = false
+		if endpoint == "" && c.pageSize > 0 && len(decoded) == c.pageSize {
+			query.Set("marker", idOf(decoded[len(decoded)-1]))
+			endpoint = base + "?" + query.Encode()
+			viaMarker = true
+		}
+	}
+	if endpoint != "" {
+		return nil, fmt.Errorf("neutron list %s: more than %d pages; refusing partial result", resource, maxListPages)
+	}
+	return items, nil
+}
+
+// getPage는 목록 한 페이지를 조회해 <resource> 배열과 <resource>_links를 반환한다.
+func (c *NeutronClient) getPage(ctx context.Context, token, endpoint, resource string) (json.RawMessage, []link, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	req.Header.Set("X-Auth-Token", token)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("neutron: unexpected status %d", resp.StatusCode)
+		return nil, nil, unexpectedStatus("neutron", "list "+resource, resp)
 	}
-	var out subnetsResponse
+	var out map[string]json.RawMessage
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	var links []link
+	if raw, ok := out[resource+"_links"]; ok {
+		if err := json.Unmarshal(raw, &links); err != nil {
+			return nil, nil, err
+		}
+	}
+	items := out[resource]
+	if items == nil {
+		items = json.RawMessage("[]")
 	}
-	return out.Subnets, nil
+	return items, links, nil
 }
 
 // GetSubnet fetches a Neutron subnet by ID.
@@ -203,7 +298,7 @@ func (c *NeutronClient) GetSubnet(ctx context.Context, token, subnetID string) (
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return Subnet{}, fmt.Errorf("neutron: unexpected status %d", resp.StatusCode)
+		return Subnet{}, unexpectedStatus("neutron", "get subnet", resp)
 	}
 	var out subnetResponse
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
@@ -229,7 +324,7 @@ func (c *NeutronClient) GetNetwork(ctx context.Context, token, networkID string)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return Network{}, fmt.Errorf("neutron: unexpected status %d", resp.StatusCode)
+		return Network{}, unexpectedStatus("neutron", "get network", resp)
 	}
 	var out networkResponse
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
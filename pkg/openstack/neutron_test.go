@@ -0,0 +1,183 @@
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListPorts_FollowsNextLinks(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// handler goroutine에서는 FailNow를 쓸 수 없으므로 Errorf 후 오류 응답을 돌려준다.
+		if r.URL.Path != "/v2.0/ports" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			http.Error(w, "unexpected path", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("limit") != "2" {
+			t.Errorf("expected limit=2, got %q", r.URL.RawQuery)
+			http.Error(w, "unexpected limit", http.StatusBadRequest)
+			return
+		}
+		resp := map[string]any{}
+		switch r.URL.Query().Get("marker") {
+		case "":
+			resp["ports"] = []Port{{ID: "p1", DeviceID: "vm-1"}, {ID: "p2", DeviceID: "vm-1"}}
+			resp["ports_links"] = []link{{Rel: "next", Href: srv.URL + "/v2.0/ports?limit=2&marker=p2&device_id=vm-1"}}
+		case "p2":
+			resp["ports"] = []Port{{ID: "p3", DeviceID: "vm-1"}}
+		default:
+			t.Errorf("unexpected marker: %s", r.URL.Query().Get("marker"))
+			http.Error(w, "unexpected marker", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer srv.Close()
+
+	c := NewNeutronClient(srv.URL, 5*time.Second, WithNeutronPageSize(2))
+	ports, err := c.ListPorts(context.Background(), "token", "", []string{"vm-1"})
+	if err != nil {
+		t.Fatalf("ListPorts error: %v", err)
+	}
+	if len(ports) != 3 || ports[2].ID != "p3" {
+		t.Fatalf("expected 3 ports across pages, got %+v", ports)
+	}
+}
+
+func TestListSubnets_MarkerWithoutLinks(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var subnets []Subnet
+		switch r.URL.Query().Get("marker") {
+		case "":
+			subnets = []Subnet{{ID: "s1"}, {ID: "s2"}}
+		case "s2":
+			subnets = []Subnet{{ID: "s3"}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"subnets": subnets})
+	}))
+	defer srv.Close()
+
+	c := NewNeutronClient(srv.URL, 5*time.Second, WithNeutronPageSize(2))
+	subnets, err := c.ListSubnets(context.Background(), "token", "project-1", "")
+	if err != nil {
+		t.Fatalf("ListSubnets error: %v", err)
+	}
+	if len(subnets) != 3 || requests != 2 {
+		t.Fatalf("expected 3 subnets in 2 requests, got %d subnets in %d requests", len(subnets), requests)
+	}
+}
+
+func TestListNetworks_PaginationIgnored(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// pagination이 꺼진 Neutron은 limit/marker를 무시하고 전체를 반환한다.
+		_ = json.NewEncoder(w).Encode(map[string]any{"networks": []Network{{ID: "n1"}, {ID: "n2"}, {ID: "n3"}}})
+	}))
+	defer srv.Close()
+
+	c := NewNeutronClient(srv.URL, 5*time.Second, WithNeutronPageSize(2))
+	networks, err := c.ListNetworks(context.Background(), "token", "")
+	if err != nil {
+		t.Fatalf("ListNetworks error: %v", err)
+	}
+	if len(networks) != 3 || requests != 1 {
+		t.Fatalf("expected 3 networks in a single request, got %d networks in %d requests", len(networks), requests)
+	}
+}
+
+func TestListNetworks_MarkerIgnored(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// pagination이 꺼진 Neutron에 정확히 limit개가 있으면 marker 요청에도 같은 목록이 온다.
+		_ = json.NewEncoder(w).Encode(map[string]any{"networks": []Network{{ID: "n1"}, {ID: "n2"}}})
+	}))
+	defer srv.Close()
+
+	c := NewNeutronClient(srv.URL, 5*time.Second, WithNeutronPageSize(2))
+	networks, err := c.ListNetworks(context.Background(), "token", "")
+	if err != nil {
+		t.Fatalf("ListNetworks error: %v", err)
+	}
+	if len(networks) != 2 || requests != 2 {
+		t.Fatalf("expected 2 networks in 2 requests, got %d networks in %d requests", len(networks), requests)
+	}
+}
+
+func TestListNetworks_NextLinkNotProgressing(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// 서버가 준 next 링크가 같은 페이지를 반복하면 일부 목록을 반환하면 안 된다.
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"networks":       []Network{{ID: "n1"}, {ID: "n2"}},
+			"networks_links": []link{{Rel: "next", Href: srv.URL + "/v2.0/networks?marker=n2"}},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewNeutronClient(srv.URL, 5*time.Second, WithNeutronPageSize(2))
+	if networks, err := c.ListNetworks(context.Background(), "token", ""); err == nil {
+		t.Fatalf("expected error when pagination does not progress, got %d networks", len(networks))
+	}
+}
+
+func TestListPorts_PageLimit(t *testing.T) {
+	requests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"ports":       []Port{{ID: fmt.Sprintf("p%d", requests), DeviceID: "vm-1"}},
+			"ports_links": []link{{Rel: "next", Href: fmt.Sprintf("%s/v2.0/ports?marker=p%d", srv.URL, requests)}},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewNeutronClient(srv.URL, 5*time.Second)
+	ports, err := c.ListPorts(context.Background(), "token", "", []string{"vm-1"})
+	if err == nil || ports != nil {
+		t.Fatalf("expected error on page limit, got %d ports (%v)", len(ports), err)
+	}
+	if requests != maxListPages {
+		t.Fatalf("expected %d requests, got %d", maxListPages, requests)
+	}
+}
+
+func TestListPorts_ChunksDeviceIDs(t *testing.T) {
+	var chunks []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query()["device_id"]
+		chunks = append(chunks, len(ids))
+		ports := make([]Port, 0, len(ids))
+		for _, id := range ids {
+			ports = append(ports, Port{ID: "port-" + id, DeviceID: id})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ports": ports})
+	}))
+	defer srv.Close()
+
+	deviceIDs := make([]string, deviceIDChunkSize+5)
+	for i := range deviceIDs {
+		deviceIDs[i] = fmt.Sprintf("vm-%03d", i)
+	}
+	c := NewNeutronClient(srv.URL, 5*time.Second)
+	ports, err := c.ListPorts(context.Background(), "token", "project-1", deviceIDs)
+	if err != nil {
+		t.Fatalf("ListPorts error: %v", err)
+	}
+	if len(ports) != len(deviceIDs) {
+		t.Fatalf("expected %d ports, got %d", len(deviceIDs), len(ports))
+	}
+	if len(chunks) != 2 || chunks[0] != deviceIDChunkSize || chunks[1] != 5 {
+		t.Fatalf("unexpected device_id chunks: %v", chunks)
+	}
+}
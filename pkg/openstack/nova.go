@@ -5,31 +5,44 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
+
+	"multinic-operator/pkg/httpretry"
+	"multinic-operator/pkg/httptransport"
 )
 
 type NovaClient struct {
 	baseURL    string
 	httpClient *http.Client
+	retry      httpretry.Policy
+
+	tlsConfig   *tls.Config
+	insecureTLS bool
+	transport   http.RoundTripper
 }
 
 type NovaOption func(*NovaClient)
 
 func WithNovaInsecureTLS(insecure bool) NovaOption {
-	return func(c *NovaClient) {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure}, //nolint:gosec
-			DialContext: (&net.Dialer{
-				Timeout:   5 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			ForceAttemptHTTP2: true,
-		}
-		c.httpClient.Transport = tr
-	}
+	return func(c *NovaClient) { c.insecureTLS = insecure }
+}
+
+// WithNovaTLSConfig는 CA bundle/클라이언트 인증서를 담은 TLS 설정을 지정한다.
+func WithNovaTLSConfig(cfg *tls.Config) NovaOption {
+	return func(c *NovaClient) { c.tlsConfig = cfg }
+}
+
+// WithNovaTransport는 공유 transport를 사용하게 한다. 지정하면 TLS 관련 옵션은 무시된다.
+func WithNovaTransport(rt http.RoundTripper) NovaOption {
+	return func(c *NovaClient) { c.transport = rt }
+}
+
+// WithNovaRetry는 조회(GET) 요청 재시도 정책을 지정한다.
+func WithNovaRetry(policy httpretry.Policy) NovaOption {
+	return func(c *NovaClient) { c.retry = policy }
 }
 
 func NewNovaClient(baseURL string, timeout time.Duration, opts ...NovaOption) *NovaClient {
@@ -37,14 +50,16 @@ func NewNovaClient(baseURL string, timeout time.Duration, opts ...NovaOption) *N
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: timeout,
-			Transport: &http.Transport{
-				ForceAttemptHTTP2: true,
-			},
 		},
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	rt := c.transport
+	if rt == nil {
+		rt = httptransport.New(c.tlsConfig, c.insecureTLS)
+	}
+	c.httpClient.Transport = httpretry.NewTransport(rt, c.retry)
 	return c
 }
 
@@ -52,12 +67,32 @@ type Server struct {
 	ID       string            `json:"id"`
 	Name     string            `json:"name"`
 	Metadata map[string]string `json:"metadata"`
+	Tags     []string          `json:"tags,omitempty"`
 }
 
 type serverResponse struct {
 	Server Server `json:"server"`
 }
 
+type serversResponse struct {
+	Servers []Server `json:"servers"`
+	Links   []link   `json:"servers_links"`
+}
+
+type link struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+// novaTagsMicroversion은 서버 tags 필터/응답을 지원하는 최소 microversion이다.
+const novaTagsMicroversion = "2.26"
+
+// serverIDChunkSize는 한 요청에 넣는 uuid 파라미터 수이다. (URL 길이 제한 방지)
+const serverIDChunkSize = 50
+
+// maxListPages는 next 링크를 따라가는 최대 페이지 수(무한 루프 방지)이다.
+const maxListPages = 1000
+
 // GetServer fetches a server by ID from Nova.
 // VM ID 기준으로 서버 상세를 조회한다.
 func (c *NovaClient) GetServer(ctx context.Context, token, serverID string) (Server, error) {
@@ -75,7 +110,7 @@ func (c *NovaClient) GetServer(ctx context.Context, token, serverID string) (Ser
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return Server{}, fmt.Errorf("nova: unexpected status %d", resp.StatusCode)
+		return Server{}, unexpectedStatus("nova", "get server", resp)
 	}
 
 	var out serverResponse
@@ -84,3 +119,104 @@ func (c *NovaClient) GetServer(ctx context.Context, token, serverID string) (Ser
 	}
 	return out.Server, nil
 }
+
+// ListServers lists servers (detail) in the token's project, optionally filtered by tags.
+// 프로젝트 내 서버 목록을 조회하며, servers_links의 next 링크를 따라 모든 페이지를 가져온다.
+func (c *NovaClient) ListServers(ctx context.Context, token string, tags []string) ([]Server, error) {
+	q := url.Values{}
+	if len(tags) > 0 {
+		q.Set("tags", strings.Join(tags, ","))
+	}
+	return c.listServers(ctx, token, q)
+}
+
+// ListServersByIDs lists servers (detail) matching the given IDs with uuid filters.
+// uuid 필터를 무시하는 Nova도 있으므로 응답은 클라이언트에서 다시 거르며,
+// 응답에 없는 ID는 호출자가 GetServer로 개별 조회해야 한다.
+func (c *NovaClient) ListServersByIDs(ctx context.Context, token string, serverIDs []string) ([]Server, error) {
+	want := make(map[string]struct{}, len(serverIDs))
+	for _, id := range serverIDs {
+		want[id] = struct{}{}
+	}
+	var servers []Server
+	seen := make(map[string]struct{}, len(serverIDs))
+	for start := 0; start < len(serverIDs); start += serverIDChunkSize {
+		end := min(start+serverIDChunkSize, len(serverIDs))
+		q := url.Values{}
+		for _, id := range serverIDs[start:end] {
+			q.Add("uuid", id)
+		}
+		page, err := c.listServers(ctx, token, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range page {
+			if _, ok := want[server.ID]; !ok {
+				continue
+			}
+			if _, dup := seen[server.ID]; dup {
+				continue
+			}
+			seen[server.ID] = struct{}{}
+			servers = append(servers, server)
+		}
+	}
+	return servers, nil
+}
+
+func (c *NovaClient) listServers(ctx context.Context, token string, q url.Values) ([]Server, error) {
+	endpoint := c.baseURL + "/servers/detail"
+	if len(q) > 0 {
+		endpoint += "?" + q.Encode()
+	}
+
+	var servers []Server
+	for page := 0; endpoint != "" && page < maxListPages; page++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Auth-Token", token)
+		req.Header.Set("OpenStack-API-Version", "compute "+novaTagsMicroversion)
+		req.Header.Set("X-OpenStack-Nova-API-Version", novaTagsMicroversion)
+
+		out, err := c.doListServers(req)
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, out.Servers...)
+		endpoint = nextLink(out.Links)
+	}
+	// 일부만 받은 목록을 반환하면 호출자가 빠진 VM을 삭제 대상으로 오판하므로 실패로 처리한다.
+	if endpoint != "" {
+		return nil, fmt.Errorf("nova list servers: more than %d pages; refusing partial result", maxListPages)
+	}
+	return servers, nil
+}
+
+func (c *NovaClient) doListServers(req *http.Request) (serversResponse, error) {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return serversResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return serversResponse{}, unexpectedStatus("nova", "list servers", resp)
+	}
+	var out serversResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return serversResponse{}, err
+	}
+	return out, nil
+}
+
+// nextLink는 *_links 목록에서 rel=next 링크를 반환한다.
+func nextLink(links []link) string {
+	for _, l := range links {
+		if strings.EqualFold(l.Rel, "next") {
+			return l.Href
+		}
+	}
+	return ""
+}
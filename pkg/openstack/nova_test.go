@@ -0,0 +1,92 @@
+package openstack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestListServersByIDs_FiltersClientSide(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// handler goroutine에서는 FailNow를 쓸 수 없으므로 Errorf 후 오류 응답을 돌려준다.
+		if r.URL.Path != "/servers/detail" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+			http.Error(w, "unexpected path", http.StatusBadRequest)
+			return
+		}
+		if got := r.URL.Query()["uuid"]; len(got) != 2 {
+			t.Errorf("expected 2 uuid filters, got %v", got)
+			http.Error(w, "unexpected uuid filters", http.StatusBadRequest)
+			return
+		}
+		// uuid 필터를 무시하는 Nova는 프로젝트의 모든 서버를 반환한다.
+		_ = json.NewEncoder(w).Encode(map[string]any{"servers": []Server{
+			{ID: "vm-1", Name: "node-1"},
+			{ID: "vm-2", Name: "node-2"},
+			{ID: "vm-3", Name: "other"},
+		}})
+	}))
+	defer srv.Close()
+
+	c := NewNovaClient(srv.URL, 5*time.Second)
+	servers, err := c.ListServersByIDs(context.Background(), "token", []string{"vm-1", "vm-2"})
+	if err != nil {
+		t.Fatalf("ListServersByIDs error: %v", err)
+	}
+	if len(servers) != 2 || servers[0].ID != "vm-1" || servers[1].ID != "vm-2" {
+		t.Fatalf("expected only requested servers, got %+v", servers)
+	}
+}
+
+func TestListServersByIDs_ChunksIDs(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := len(r.URL.Query()["uuid"]); got > serverIDChunkSize {
+			t.Errorf("expected at most %d uuid filters, got %d", serverIDChunkSize, got)
+			http.Error(w, "too many uuid filters", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"servers": []Server{}})
+	}))
+	defer srv.Close()
+
+	ids := make([]string, serverIDChunkSize+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("vm-%d", i)
+	}
+	c := NewNovaClient(srv.URL, 5*time.Second)
+	if _, err := c.ListServersByIDs(context.Background(), "token", ids); err != nil {
+		t.Fatalf("ListServersByIDs error: %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestListServers_PageLimit(t *testing.T) {
+	requests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// next 링크가 끝나지 않으면 최대 페이지 수에서 멈추고 일부 목록 대신 오류를 반환해야 한다.
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"servers":       []Server{{ID: fmt.Sprintf("vm-%d", requests)}},
+			"servers_links": []link{{Rel: "next", Href: fmt.Sprintf("%s/servers/detail?marker=vm-%d", srv.URL, requests)}},
+		})
+	}))
+	defer srv.Close()
+
+	c := NewNovaClient(srv.URL, 5*time.Second)
+	servers, err := c.ListServers(context.Background(), "token", nil)
+	if err == nil || servers != nil {
+		t.Fatalf("expected error on page limit, got %d servers (%v)", len(servers), err)
+	}
+	if requests != maxListPages {
+		t.Fatalf("expected %d requests, got %d", maxListPages, requests)
+	}
+}
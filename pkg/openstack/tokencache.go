@@ -0,0 +1,103 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultTokenRefreshBefore는 만료 전 이 시간 안으로 들어오면 토큰을 새로 발급한다.
+const DefaultTokenRefreshBefore = 5 * time.Minute
+
+type tokenKey struct {
+	keystoneURL string
+	domain      string
+	username    string
+	projectID   string
+}
+
+// TokenCache는 (keystone URL, domain, user, project) 단위로 Keystone 토큰을 재사용한다.
+// 만료 시각(expires_at)을 알 수 없는 토큰은 캐시하지 않는다.
+type TokenCache struct {
+	refreshBefore time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	entries map[tokenKey]Token
+}
+
+func NewTokenCache(refreshBefore time.Duration) *TokenCache {
+	if refreshBefore <= 0 {
+		refreshBefore = DefaultTokenRefreshBefore
+	}
+	return &TokenCache{
+		refreshBefore: refreshBefore,
+		now:           time.Now,
+		entries:       make(map[tokenKey]Token),
+	}
+}
+
+func cacheKey(ks *KeystoneClient, username, projectID string) tokenKey {
+	return tokenKey{keystoneURL: ks.baseURL, domain: ks.domain, username: username, projectID: projectID}
+}
+
+// Token은 유효한 캐시 토큰을 반환하고, 없거나 만료가 임박하면 새로 발급한다.
+func (c *TokenCache) Token(ctx context.Context, ks *KeystoneClient, username, password, projectID string) (Token, error) {
+	key := cacheKey(ks, username, projectID)
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Add(c.refreshBefore).Before(cached.ExpiresAt) {
+		return cached, nil
+	}
+
+	token, err := ks.IssueToken(ctx, username, password, projectID)
+	if err != nil {
+		return Token{}, err
+	}
+	c.mu.Lock()
+	if token.ExpiresAt.IsZero() {
+		delete(c.entries, key)
+	} else {
+		c.entries[key] = token
+	}
+	c.mu.Unlock()
+	return token, nil
+}
+
+// Invalidate는 캐시된 토큰을 폐기한다. (401 응답 시 사용)
+func (c *TokenCache) Invalidate(ks *KeystoneClient, username, projectID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, cacheKey(ks, username, projectID))
+}
+
+// InvalidateUser는 해당 사용자의 모든 프로젝트 토큰을 폐기한다. (자격증명 변경 시 사용)
+func (c *TokenCache) InvalidateUser(ks *KeystoneClient, username string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if key.keystoneURL == ks.baseURL && key.domain == ks.domain && key.username == username {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// WithRetry는 토큰으로 fn을 실행하고, ErrUnauthorized이면 토큰을 폐기한 뒤 한 번만 재시도한다.
+func (c *TokenCache) WithRetry(ctx context.Context, ks *KeystoneClient, username, password, projectID string, fn func(token string) error) error {
+	token, err := c.Token(ctx, ks, username, password, projectID)
+	if err != nil {
+		return err
+	}
+	err = fn(token.ID)
+	if !errors.Is(err, ErrUnauthorized) {
+		return err
+	}
+	c.Invalidate(ks, username, projectID)
+	token, err = c.Token(ctx, ks, username, password, projectID)
+	if err != nil {
+		return err
+	}
+	return fn(token.ID)
+}
@@ -0,0 +1,115 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newKeystoneServer(t *testing.T, expiresAt string, issued *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/auth/tokens" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		n := atomic.AddInt32(issued, 1)
+		w.Header().Set("X-Subject-Token", fmt.Sprintf("token-%d", n))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token":{"expires_at":%q,"catalog":[]}}`, expiresAt)
+	}))
+}
+
+func TestTokenCache_ReuseUntilNearExpiry(t *testing.T) {
+	var issued int32
+	expiresAt := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+	srv := newKeystoneServer(t, expiresAt.Format("2006-01-02T15:04:05.000000Z"), &issued)
+	defer srv.Close()
+
+	ks := NewKeystoneClient(srv.URL, "default", 5*time.Second)
+	cache := NewTokenCache(5 * time.Minute)
+	now := expiresAt.Add(-time.Hour)
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		token, err := cache.Token(context.Background(), ks, "admin", "pw", "project-1")
+		if err != nil {
+			t.Fatalf("Token error: %v", err)
+		}
+		if token.ID != "token-1" || !token.ExpiresAt.Equal(expiresAt) {
+			t.Fatalf("unexpected token: %+v", token)
+		}
+	}
+	if issued != 1 {
+		t.Fatalf("expected 1 issuance, got %d", issued)
+	}
+
+	now = expiresAt.Add(-time.Minute)
+	if _, err := cache.Token(context.Background(), ks, "admin", "pw", "project-1"); err != nil {
+		t.Fatalf("Token error: %v", err)
+	}
+	if issued != 2 {
+		t.Fatalf("expected refresh near expiry, got %d issuances", issued)
+	}
+
+	if _, err := cache.Token(context.Background(), ks, "admin", "pw", "project-2"); err != nil {
+		t.Fatalf("Token error: %v", err)
+	}
+	if issued != 3 {
+		t.Fatalf("expected separate token per project, got %d issuances", issued)
+	}
+}
+
+func TestTokenCache_NoExpiryNotCached(t *testing.T) {
+	var issued int32
+	srv := newKeystoneServer(t, "", &issued)
+	defer srv.Close()
+
+	ks := NewKeystoneClient(srv.URL, "default", 5*time.Second)
+	cache := NewTokenCache(0)
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Token(context.Background(), ks, "admin", "pw", "project-1"); err != nil {
+			t.Fatalf("Token error: %v", err)
+		}
+	}
+	if issued != 2 {
+		t.Fatalf("expected token without expires_at to be reissued, got %d issuances", issued)
+	}
+}
+
+func TestTokenCache_WithRetryOnUnauthorized(t *testing.T) {
+	var issued int32
+	srv := newKeystoneServer(t, time.Now().Add(time.Hour).UTC().Format(time.RFC3339), &issued)
+	defer srv.Close()
+
+	ks := NewKeystoneClient(srv.URL, "default", 5*time.Second)
+	cache := NewTokenCache(5 * time.Minute)
+
+	var seen []string
+	err := cache.WithRetry(context.Background(), ks, "admin", "pw", "project-1", func(token string) error {
+		seen = append(seen, token)
+		if token == "token-1" {
+			return unexpectedStatus("neutron", "list ports", &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithRetry error: %v", err)
+	}
+	if len(seen) != 2 || seen[1] != "token-2" {
+		t.Fatalf("expected retry with new token, got %v", seen)
+	}
+
+	calls := 0
+	err = cache.WithRetry(context.Background(), ks, "admin", "pw", "project-1", func(token string) error {
+		calls++
+		return unexpectedStatus("nova", "get server", &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{}})
+	})
+	if !errors.Is(err, ErrUnauthorized) || calls != 2 {
+		t.Fatalf("expected single retry then ErrUnauthorized, got calls=%d err=%v", calls, err)
+	}
+}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
-	"fmt"
-	"net"
 	"net/http"
 	"time"
+
+	"multinic-operator/pkg/apierror"
+	"multinic-operator/pkg/httpretry"
+	"multinic-operator/pkg/httptransport"
 )
 
 type Client struct {
@@ -16,6 +18,12 @@ type Client struct {
 	authToken  string
 	providerID string
 	httpClient *http.Client
+	observe    func(method string, statusCode int)
+	retry      httpretry.Policy
+
+	tlsConfig   *tls.Config
+	insecureTLS bool
+	transport   http.RoundTripper
 }
 
 type Option func(*Client)
@@ -28,18 +36,29 @@ func WithProviderID(providerID string) Option {
 	return func(c *Client) { c.providerID = providerID }
 }
 
+// WithResponseObserver는 요청마다 응답 코드를 전달받는다. (전송 실패 시 0)
+func WithResponseObserver(fn func(method string, statusCode int)) Option {
+	return func(c *Client) { c.observe = fn }
+}
+
 func WithInsecureTLS(insecure bool) Option {
-	return func(c *Client) {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure}, //nolint:gosec
-			DialContext: (&net.Dialer{
-				Timeout:   5 * time.Second,
-				KeepAlive: 30 * time.Second,
-			}).DialContext,
-			ForceAttemptHTTP2: true,
-		}
-		c.httpClient.Transport = tr
-	}
+	return func(c *Client) { c.insecureTLS = insecure }
+}
+
+// WithTLSConfig는 CA bundle/클라이언트 인증서를 담은 TLS 설정을 지정한다.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) { c.tlsConfig = cfg }
+}
+
+// WithTransport는 공유 transport를 사용하게 한다. 지정하면 TLS 관련 옵션은 무시된다.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) { c.transport = rt }
+}
+
+// WithRetry는 재시도 정책을 지정한다. DELETE는 404를 삭제 완료로 보므로 재시도하지만,
+// POST(전송)는 중복 반영을 피하기 위해 재시도하지 않고 requeue에 맡긴다.
+func WithRetry(policy httpretry.Policy) Option {
+	return func(c *Client) { c.retry = policy }
 }
 
 func NewClient(baseURL string, timeout time.Duration, opts ...Option) *Client {
@@ -47,14 +66,16 @@ func NewClient(baseURL string, timeout time.Duration, opts ...Option) *Client {
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: timeout,
-			Transport: &http.Transport{
-				ForceAttemptHTTP2: true,
-			},
 		},
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	rt := c.transport
+	if rt == nil {
+		rt = httptransport.New(c.tlsConfig, c.insecureTLS)
+	}
+	c.httpClient.Transport = httpretry.NewTransport(rt, c.retry, http.MethodGet, http.MethodHead, http.MethodDelete)
 	return c
 }
 
@@ -78,6 +99,12 @@ type NodeConfig struct {
 	Interfaces []NodeInterface `json:"interfaces"`
 }
 
+// NodeRef는 삭제 요청에서 대상 노드를 식별한다.
+type NodeRef struct {
+	NodeName   string `json:"nodeName"`
+	InstanceID string `json:"instanceId,omitempty"`
+}
+
 type batchResponse struct {
 	Results any `json:"results"`
 	Errors  any `json:"errors"`
@@ -102,15 +129,65 @@ func (c *Client) SendNodeConfigs(ctx context.Context, nodes []NodeConfig) error
 		req.Header.Set("x-provider-id", c.providerID)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return fmt.Errorf("viola: unexpected status %d", resp.StatusCode)
+		return apierror.FromResponse("viola", "send node configs", resp)
 	}
 	// Response body is optional; ignore content for now.
 	return nil
 }
+
+// DeleteNodeConfigs asks Viola API to delete node configs previously sent.
+// Operator가 생성했던 Agent용 CR 삭제 요청을 Viola API에 전송한다.
+func (c *Client) DeleteNodeConfigs(ctx context.Context, nodes []NodeRef) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(nodes)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, c.baseURL+"/v1/k8s/multinic/node-configs", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.authToken)
+	}
+	if c.providerID != "" {
+		req.Header.Set("x-provider-id", c.providerID)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound:
+		// 404는 이미 삭제된 것으로 간주한다.
+		return nil
+	default:
+		return apierror.FromResponse("viola", "delete node configs", resp)
+	}
+}
+
+// do는 요청을 보내고 응답 코드를 observer에 전달한다.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.httpClient.Do(req)
+	if c.observe != nil {
+		code := 0
+		if err == nil {
+			code = resp.StatusCode
+		}
+		c.observe(req.Method, code)
+	}
+	return resp, err
+}
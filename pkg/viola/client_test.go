@@ -0,0 +1,95 @@
+package viola
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"multinic-operator/pkg/apierror"
+)
+
+func TestDeleteNodeConfigs(t *testing.T) {
+	// handler는 별도 goroutine에서 실행되므로 요청만 기록하고 검증은 테스트 goroutine에서 한다.
+	var (
+		gotMethod, gotPath, gotProvider string
+		gotBody                         []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath, gotProvider = r.Method, r.URL.Path, r.Header.Get("x-provider-id")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, 5*time.Second, WithProviderID("provider-1"))
+	if err := c.DeleteNodeConfigs(context.Background(), []NodeRef{{NodeName: "worker-1", InstanceID: "vm-1"}}); err != nil {
+		t.Fatalf("DeleteNodeConfigs error: %v", err)
+	}
+	if gotMethod != http.MethodDelete {
+		t.Fatalf("unexpected method: %s", gotMethod)
+	}
+	if gotPath != "/v1/k8s/multinic/node-configs" {
+		t.Fatalf("unexpected path: %s", gotPath)
+	}
+	if gotProvider != "provider-1" {
+		t.Fatalf("unexpected provider header: %q", gotProvider)
+	}
+	var refs []NodeRef
+	if err := json.Unmarshal(gotBody, &refs); err != nil {
+		t.Fatalf("invalid body: %v", err)
+	}
+	if len(refs) != 1 || refs[0].NodeName != "worker-1" || refs[0].InstanceID != "vm-1" {
+		t.Fatalf("unexpected refs: %+v", refs)
+	}
+}
+
+func TestDeleteNodeConfigs_Status(t *testing.T) {
+	status := http.StatusNotFound
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, 5*time.Second)
+	refs := []NodeRef{{NodeName: "worker-1"}}
+	if err := c.DeleteNodeConfigs(context.Background(), refs); err != nil {
+		t.Fatalf("expected 404 to be treated as deleted, got %v", err)
+	}
+
+	status = http.StatusInternalServerError
+	err := c.DeleteNodeConfigs(context.Background(), refs)
+	if apierror.StatusCode(err) != http.StatusInternalServerError {
+		t.Fatalf("expected typed 500 error, got %v", err)
+	}
+}
+
+func TestResponseObserver(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	var gotMethod string
+	var gotCode int
+	c := NewClient(srv.URL, 5*time.Second, WithResponseObserver(func(method string, statusCode int) {
+		gotMethod, gotCode = method, statusCode
+	}))
+	if err := c.SendNodeConfigs(context.Background(), []NodeConfig{{NodeName: "worker-1"}}); err != nil {
+		t.Fatalf("SendNodeConfigs error: %v", err)
+	}
+	if gotMethod != http.MethodPost || gotCode != http.StatusAccepted {
+		t.Fatalf("unexpected observation: %s %d", gotMethod, gotCode)
+	}
+
+	srv.Close()
+	if err := c.SendNodeConfigs(context.Background(), []NodeConfig{{NodeName: "worker-1"}}); err == nil {
+		t.Fatalf("expected transport error")
+	}
+	if gotCode != 0 {
+		t.Fatalf("expected status 0 on transport error, got %d", gotCode)
+	}
+}
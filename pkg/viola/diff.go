@@ -0,0 +1,198 @@
+package viola
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// 인터페이스 변경 종류
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// FieldChange는 필드 하나의 이전/현재 값이다. 필드 이름은 JSON 키를 사용한다.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// InterfaceChange는 인터페이스 하나의 변경 내용이다.
+// added/removed는 Interface에 해당 인터페이스 전체를, modified는 Fields에 바뀐 필드를 담는다.
+type InterfaceChange struct {
+	Type      string        `json:"type"`
+	PortID    string        `json:"portId,omitempty"`
+	MAC       string        `json:"macAddress,omitempty"`
+	Name      string        `json:"name,omitempty"`
+	Fields    []FieldChange `json:"fields,omitempty"`
+	Interface NodeInterface `json:"interface"`
+}
+
+// NodeConfigDiff는 같은 노드의 두 NodeConfig 차이이다.
+type NodeConfigDiff struct {
+	NodeName   string            `json:"nodeName"`
+	InstanceID *FieldChange      `json:"instanceId,omitempty"`
+	Interfaces []InterfaceChange `json:"interfaces"`
+}
+
+// Empty는 차이가 없으면 true이다.
+func (d NodeConfigDiff) Empty() bool {
+	return d.InstanceID == nil && len(d.Interfaces) == 0
+}
+
+// String은 로그/이벤트용 요약이다. (예: "multinic2 mtu 1500→9000; +multinic3 10.0.1.5/24")
+func (d NodeConfigDiff) String() string {
+	parts := make([]string, 0, len(d.Interfaces)+1)
+	if d.InstanceID != nil {
+		parts = append(parts, fmt.Sprintf("instanceId %s→%s", d.InstanceID.From, d.InstanceID.To))
+	}
+	for _, c := range d.Interfaces {
+		parts = append(parts, c.String())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// String은 인터페이스 변경 한 건의 요약이다.
+func (c InterfaceChange) String() string {
+	label := c.label()
+	switch c.Type {
+	case ChangeAdded:
+		return "+" + label + describeInterface(c.Interface)
+	case ChangeRemoved:
+		return "-" + label + describeInterface(c.Interface)
+	}
+	fields := make([]string, 0, len(c.Fields))
+	for _, f := range c.Fields {
+		fields = append(fields, fmt.Sprintf("%s %s→%s", f.Field, displayValue(f.From), displayValue(f.To)))
+	}
+	return label + " " + strings.Join(fields, ", ")
+}
+
+func (c InterfaceChange) label() string {
+	switch {
+	case c.Name != "":
+		return c.Name
+	case c.PortID != "":
+		return c.PortID
+	default:
+		return c.MAC
+	}
+}
+
+func describeInterface(iface NodeInterface) string {
+	addr := iface.Address
+	if iface.CIDR != "" {
+		if _, prefix, ok := strings.Cut(iface.CIDR, "/"); ok && addr != "" {
+			addr += "/" + prefix
+		} else if addr == "" {
+			addr = iface.CIDR
+		}
+	}
+	if addr == "" {
+		return ""
+	}
+	return " " + addr
+}
+
+func displayValue(v string) string {
+	if v == "" {
+		return `""`
+	}
+	return v
+}
+
+// DiffNodeConfigs는 previous→current 인터페이스 변경을 계산한다.
+// 인터페이스는 PortID로 먼저 대응시키고, PortID가 없거나 대응되지 않으면 MAC으로 대응시킨다.
+// 결과 순서는 current 인터페이스 순서(추가/변경) 다음 previous 순서(제거)이다.
+func DiffNodeConfigs(previous, current NodeConfig) NodeConfigDiff {
+	diff := NodeConfigDiff{NodeName: current.NodeName, Interfaces: make([]InterfaceChange, 0)}
+	if diff.NodeName == "" {
+		diff.NodeName = previous.NodeName
+	}
+	if previous.InstanceID != current.InstanceID && previous.InstanceID != "" && current.InstanceID != "" {
+		diff.InstanceID = &FieldChange{Field: "instanceId", From: previous.InstanceID, To: current.InstanceID}
+	}
+
+	matched := make([]bool, len(previous.Interfaces))
+	byPort := make(map[string]int)
+	byMAC := make(map[string]int)
+	for i, iface := range previous.Interfaces {
+		if iface.PortID != "" {
+			byPort[iface.PortID] = i
+		}
+		if mac := normalizeMAC(iface.MAC); mac != "" {
+			byMAC[mac] = i
+		}
+	}
+	find := func(iface NodeInterface) (int, bool) {
+		if i, ok := byPort[iface.PortID]; ok && iface.PortID != "" && !matched[i] {
+			return i, true
+		}
+		if i, ok := byMAC[normalizeMAC(iface.MAC)]; ok && iface.MAC != "" && !matched[i] {
+			return i, true
+		}
+		return 0, false
+	}
+
+	for _, iface := range current.Interfaces {
+		i, ok := find(iface)
+		if !ok {
+			diff.Interfaces = append(diff.Interfaces, newInterfaceChange(ChangeAdded, iface))
+			continue
+		}
+		matched[i] = true
+		if fields := diffInterface(previous.Interfaces[i], iface); len(fields) > 0 {
+			change := newInterfaceChange(ChangeModified, iface)
+			change.Fields = fields
+			diff.Interfaces = append(diff.Interfaces, change)
+		}
+	}
+	for i, iface := range previous.Interfaces {
+		if !matched[i] {
+			diff.Interfaces = append(diff.Interfaces, newInterfaceChange(ChangeRemoved, iface))
+		}
+	}
+	return diff
+}
+
+func newInterfaceChange(changeType string, iface NodeInterface) InterfaceChange {
+	return InterfaceChange{Type: changeType, PortID: iface.PortID, MAC: iface.MAC, Name: iface.Name, Interface: iface}
+}
+
+// diffInterface는 대응된 두 인터페이스의 바뀐 필드를 고정 순서로 반환한다.
+func diffInterface(previous, current NodeInterface) []FieldChange {
+	pairs := []struct {
+		field    string
+		from, to string
+	}{
+		{"name", previous.Name, current.Name},
+		{"portId", previous.PortID, current.PortID},
+		{"macAddress", normalizeMAC(previous.MAC), normalizeMAC(current.MAC)},
+		{"address", previous.Address, current.Address},
+		{"cidr", previous.CIDR, current.CIDR},
+		{"mtu", formatMTU(previous.MTU), formatMTU(current.MTU)},
+		{"networkId", previous.NetworkID, current.NetworkID},
+		{"subnetId", previous.SubnetID, current.SubnetID},
+	}
+	var out []FieldChange
+	for _, p := range pairs {
+		if p.from != p.to {
+			out = append(out, FieldChange{Field: p.field, From: p.from, To: p.to})
+		}
+	}
+	return out
+}
+
+func normalizeMAC(mac string) string {
+	return strings.ToLower(strings.TrimSpace(mac))
+}
+
+func formatMTU(mtu int) string {
+	if mtu == 0 {
+		return ""
+	}
+	return strconv.Itoa(mtu)
+}
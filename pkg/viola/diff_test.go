@@ -0,0 +1,55 @@
+package viola
+
+import "testing"
+
+func TestDiffNodeConfigs(t *testing.T) {
+	previous := NodeConfig{
+		NodeName:   "worker-1",
+		InstanceID: "vm-1",
+		Interfaces: []NodeInterface{
+			{ID: 0, PortID: "port-1", Name: "multinic0", MAC: "fa:16:3e:00:00:01", Address: "10.0.0.5", CIDR: "10.0.0.0/24", MTU: 1500},
+			{ID: 1, PortID: "port-2", Name: "multinic1", MAC: "fa:16:3e:00:00:02", Address: "10.0.1.5", CIDR: "10.0.1.0/24", MTU: 1500},
+			{ID: 2, PortID: "port-3", Name: "multinic2", MAC: "fa:16:3e:00:00:03", Address: "10.0.2.5", CIDR: "10.0.2.0/24", MTU: 1500},
+			{ID: 3, Name: "multinic3", MAC: "FA:16:3E:00:00:04", Address: "10.0.3.5", CIDR: "10.0.3.0/24", MTU: 1500},
+		},
+	}
+	current := NodeConfig{
+		NodeName:   "worker-1",
+		InstanceID: "vm-1",
+		Interfaces: []NodeInterface{
+			// port-1 제거로 이름이 한 칸씩 당겨지고, port-3은 MTU도 바뀐다.
+			{ID: 0, PortID: "port-2", Name: "multinic0", MAC: "fa:16:3e:00:00:02", Address: "10.0.1.5", CIDR: "10.0.1.0/24", MTU: 1500},
+			{ID: 1, PortID: "port-3", Name: "multinic1", MAC: "fa:16:3e:00:00:03", Address: "10.0.2.5", CIDR: "10.0.2.0/24", MTU: 9000},
+			// PortID가 없으면 MAC(대소문자 무시)으로 대응시킨다.
+			{ID: 2, Name: "multinic2", MAC: "fa:16:3e:00:00:04", Address: "10.0.3.6", CIDR: "10.0.3.0/24", MTU: 1500},
+			{ID: 3, PortID: "port-5", Name: "multinic3", MAC: "fa:16:3e:00:00:05", Address: "10.0.5.5", CIDR: "10.0.5.0/24", MTU: 1500},
+		},
+	}
+
+	diff := DiffNodeConfigs(previous, current)
+	want := "multinic0 name multinic1→multinic0; " +
+		"multinic1 name multinic2→multinic1, mtu 1500→9000; " +
+		"multinic2 name multinic3→multinic2, address 10.0.3.5→10.0.3.6; " +
+		"+multinic3 10.0.5.5/24; " +
+		"-multinic0 10.0.0.5/24"
+	if got := diff.String(); got != want {
+		t.Fatalf("unexpected diff:\n got: %s\nwant: %s", got, want)
+	}
+	if diff.Interfaces[3].Type != ChangeAdded || diff.Interfaces[4].Type != ChangeRemoved || diff.Interfaces[4].PortID != "port-1" {
+		t.Fatalf("unexpected change types: %+v", diff.Interfaces)
+	}
+
+	if d := DiffNodeConfigs(previous, previous); !d.Empty() || d.String() != "" {
+		t.Fatalf("expected no diff for identical configs, got %q", d.String())
+	}
+
+	replaced := current
+	replaced.InstanceID = "vm-2"
+	if d := DiffNodeConfigs(current, replaced); d.Empty() || d.String() != "instanceId vm-1→vm-2" {
+		t.Fatalf("expected instance change, got %q", d.String())
+	}
+
+	if d := DiffNodeConfigs(NodeConfig{}, current); len(d.Interfaces) != 4 || d.NodeName != "worker-1" {
+		t.Fatalf("expected all interfaces added for new node, got %+v", d)
+	}
+}